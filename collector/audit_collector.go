@@ -0,0 +1,289 @@
+// Copyright 2023 enthus GmbH
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/go-kit/log"
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/exp/slices"
+)
+
+type auditCollector struct {
+	logger  log.Logger
+	options *LoginOptions
+}
+
+const (
+	auditSpace = "audit"
+)
+
+func init() {
+	registerCollector("audit", defaultDisabled, newAuditCollector)
+}
+
+// newAuditCollector returns a new Collector exposing OpenZiti policy coverage audit metrics.
+func newAuditCollector(logger log.Logger, options *LoginOptions) (Collector, error) {
+	return &auditCollector{
+		logger:  logger,
+		options: options,
+	}, nil
+}
+
+// Update pushes audit metrics onto ch
+func (c *auditCollector) Update(ch chan<- prometheus.Metric) (err error) {
+	// if not already logged, do the login.
+	if c.options == nil {
+		c.options, err = edgeAPILogin(c.logger)
+		if err != nil {
+			errString := fmt.Sprintf("%s", errors.Unwrap(err))
+			zitiLoginErrors[errString]++
+
+			return err
+		}
+
+		zitiLoginSuccess++
+	} else if c.options.Token == "" {
+		c.options, err = edgeAPILogin(c.logger)
+		if err != nil {
+			errString := fmt.Sprintf("%s", errors.Unwrap(err))
+			zitiLoginErrors[errString]++
+
+			return err
+		}
+
+		zitiLoginSuccess++
+	}
+
+	identities, err := c.options.RunAllIdentities("")
+	if err != nil {
+		return err
+	}
+
+	services, err := c.options.RunServices("")
+	if err != nil {
+		return err
+	}
+
+	routers, err := c.options.RunRouters("")
+	if err != nil {
+		return err
+	}
+
+	servicePolicies, err := c.options.RunServicePolicies()
+	if err != nil {
+		return err
+	}
+
+	edgeRouterPolicies, err := c.options.RunEdgeRouterPolicies()
+	if err != nil {
+		return err
+	}
+
+	serviceEdgeRouterPolicies, err := c.options.RunServiceEdgeRouterPolicies()
+	if err != nil {
+		return err
+	}
+
+	orphans := make(map[string][]string)
+
+	for i := range identities.Data {
+		// router identities are not meant to be selected by service policies
+		if identities.Data[i].TypeID == routerIdentityType {
+			continue
+		}
+
+		covered := false
+
+		for j := range servicePolicies.Data {
+			if policyRolesMatch(servicePolicies.Data[j].IdentityRoles, servicePolicies.Data[j].Semantic,
+				identities.Data[i].ID, identities.Data[i].RoleAttributes) {
+				covered = true
+				break
+			}
+		}
+
+		if !covered {
+			orphans["identity_without_service_policy"] = append(orphans["identity_without_service_policy"], identities.Data[i].Name)
+		}
+	}
+
+	for i := range services.Data {
+		dial, bind, serviceEdgeRouter := false, false, false
+
+		for j := range servicePolicies.Data {
+			if !policyRolesMatch(servicePolicies.Data[j].ServiceRoles, servicePolicies.Data[j].Semantic,
+				services.Data[i].ID, services.Data[i].RoleAttributes) {
+				continue
+			}
+
+			switch servicePolicies.Data[j].Type {
+			case servicePolicyDial:
+				dial = true
+			case servicePolicyBind:
+				bind = true
+			}
+		}
+
+		for j := range serviceEdgeRouterPolicies.Data {
+			if policyRolesMatch(serviceEdgeRouterPolicies.Data[j].ServiceRoles, serviceEdgeRouterPolicies.Data[j].Semantic,
+				services.Data[i].ID, services.Data[i].RoleAttributes) {
+				serviceEdgeRouter = true
+				break
+			}
+		}
+
+		if !dial {
+			orphans["service_without_dial_policy"] = append(orphans["service_without_dial_policy"], services.Data[i].Name)
+		}
+
+		if !bind {
+			orphans["service_without_bind_policy"] = append(orphans["service_without_bind_policy"], services.Data[i].Name)
+		}
+
+		if !serviceEdgeRouter {
+			orphans["service_without_service_edge_router_policy"] = append(orphans["service_without_service_edge_router_policy"], services.Data[i].Name)
+		}
+	}
+
+	for i := range routers.Data {
+		covered := false
+
+		for j := range edgeRouterPolicies.Data {
+			if policyRolesMatch(edgeRouterPolicies.Data[j].EdgeRouterRoles, edgeRouterPolicies.Data[j].Semantic,
+				routers.Data[i].ID, routers.Data[i].RoleAttributes) {
+				covered = true
+				break
+			}
+		}
+
+		if !covered {
+			orphans["router_without_edge_router_policy"] = append(orphans["router_without_edge_router_policy"], routers.Data[i].Name)
+		}
+	}
+
+	for _, kind := range []string{
+		"identity_without_service_policy",
+		"service_without_dial_policy",
+		"service_without_bind_policy",
+		"service_without_service_edge_router_policy",
+		"router_without_edge_router_policy",
+	} {
+		ch <- prometheus.MustNewConstMetric(
+			prometheus.NewDesc(
+				prometheus.BuildFQName(namespace, auditSpace,
+					"orphaned_entities"),
+				"Number of entities not selected by any policy of the kind.",
+				[]string{"kind"}, nil,
+			), prometheus.GaugeValue,
+			float64(len(orphans[kind])),
+			kind,
+		)
+
+		for _, name := range orphans[kind] {
+			ch <- prometheus.MustNewConstMetric(
+				prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, auditSpace,
+						"orphaned_entity"),
+					"Entity not selected by any policy of the kind.",
+					[]string{"kind", "name"}, nil,
+				), prometheus.GaugeValue,
+				1,
+				kind,
+				name,
+			)
+		}
+	}
+
+	// role attributes referenced by each policy type, by the entity type carrying them.
+	referenced := map[string]map[string][]string{
+		"service_policy":             {},
+		"edge_router_policy":         {},
+		"service_edge_router_policy": {},
+	}
+
+	for i := range servicePolicies.Data {
+		refs := referenced["service_policy"]
+		refs["identity"] = append(refs["identity"], policyRoleAttributes(servicePolicies.Data[i].IdentityRoles)...)
+		refs["service"] = append(refs["service"], policyRoleAttributes(servicePolicies.Data[i].ServiceRoles)...)
+	}
+
+	for i := range edgeRouterPolicies.Data {
+		refs := referenced["edge_router_policy"]
+		refs["identity"] = append(refs["identity"], policyRoleAttributes(edgeRouterPolicies.Data[i].IdentityRoles)...)
+		refs["router"] = append(refs["router"], policyRoleAttributes(edgeRouterPolicies.Data[i].EdgeRouterRoles)...)
+	}
+
+	for i := range serviceEdgeRouterPolicies.Data {
+		refs := referenced["service_edge_router_policy"]
+		refs["service"] = append(refs["service"], policyRoleAttributes(serviceEdgeRouterPolicies.Data[i].ServiceRoles)...)
+		refs["router"] = append(refs["router"], policyRoleAttributes(serviceEdgeRouterPolicies.Data[i].EdgeRouterRoles)...)
+	}
+
+	// role attributes carried by at least one entity, by entity type.
+	carried := make(map[string][]string)
+
+	for entityType, roleAttrType := range map[string]string{
+		"identity": "identity",
+		"service":  "service",
+		"router":   "edge-router",
+	} {
+		roleAttributes, err := c.options.RunRoleAttributes(roleAttrType)
+		if err != nil {
+			return err
+		}
+
+		carried[entityType] = roleAttributes.Data
+	}
+
+	for policyType, entityTypes := range referenced {
+		var dangling []string
+
+		for entityType, attributes := range entityTypes {
+			for _, attribute := range attributes {
+				if !slices.Contains(carried[entityType], attribute) && !slices.Contains(dangling, entityType+"/"+attribute) {
+					dangling = append(dangling, entityType+"/"+attribute)
+
+					ch <- prometheus.MustNewConstMetric(
+						prometheus.NewDesc(
+							prometheus.BuildFQName(namespace, auditSpace,
+								"dangling_role_attribute"),
+							"Role attribute referenced by a policy type but carried by no entity.",
+							[]string{"policy_type", "entity_type", "attribute"}, nil,
+						), prometheus.GaugeValue,
+						1,
+						policyType,
+						entityType,
+						attribute,
+					)
+				}
+			}
+		}
+
+		ch <- prometheus.MustNewConstMetric(
+			prometheus.NewDesc(
+				prometheus.BuildFQName(namespace, auditSpace,
+					"dangling_role_attributes"),
+				"Number of role attributes referenced by a policy type but carried by no entity.",
+				[]string{"policy_type"}, nil,
+			), prometheus.GaugeValue,
+			float64(len(dangling)),
+			policyType,
+		)
+	}
+
+	return nil
+}
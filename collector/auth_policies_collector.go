@@ -0,0 +1,235 @@
+// Copyright 2023 enthus GmbH
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type authPoliciesCollector struct {
+	logger  log.Logger
+	options *LoginOptions
+	filter  *entityFilter
+}
+
+const (
+	authPolicySpace = "auth_policy"
+	// only the default identities can enroll MFA, the router identities are left out
+	mfaIdentitiesFilter = "typeId = \"Default\""
+)
+
+func init() {
+	registerCollector("auth_policies", defaultDisabled, newAuthPoliciesCollector)
+}
+
+// newAuthPoliciesCollector returns a new Collector exposing OpenZiti Auth Policies metrics.
+func newAuthPoliciesCollector(logger log.Logger, options *LoginOptions) (Collector, error) {
+	filter, err := zitiAuthPolicyEntityFilter.entityFilter()
+	if err != nil {
+		return nil, err
+	}
+
+	return &authPoliciesCollector{
+		logger:  logger,
+		options: options,
+		filter:  filter,
+	}, nil
+}
+
+// Update pushes auth policies metrics onto ch
+func (c *authPoliciesCollector) Update(ch chan<- prometheus.Metric) (err error) {
+	// if not already logged, do the login.
+	if c.options == nil {
+		c.options, err = edgeAPILogin(c.logger)
+		if err != nil {
+			errString := fmt.Sprintf("%s", errors.Unwrap(err))
+			zitiLoginErrors[errString]++
+
+			return err
+		}
+
+		zitiLoginSuccess++
+	} else if c.options.Token == "" {
+		c.options, err = edgeAPILogin(c.logger)
+		if err != nil {
+			errString := fmt.Sprintf("%s", errors.Unwrap(err))
+			zitiLoginErrors[errString]++
+
+			return err
+		}
+
+		zitiLoginSuccess++
+	}
+
+	authPolicies, err := c.options.RunAuthPolicies(*zitiAuthPolicyFilter)
+	if err != nil {
+		return err
+	}
+
+	identities, err := c.options.RunAllIdentities(mfaIdentitiesFilter)
+	if err != nil {
+		return err
+	}
+
+	var (
+		policyIdentities     = make(map[string]float64)
+		policyMfaEnrolled    = make(map[string]float64)
+		policyMfaRequired    = make(map[string]bool)
+		policyMfaNotEnrolled = make(map[string]float64)
+	)
+
+	for i := range authPolicies.Data {
+		policyMfaRequired[authPolicies.Data[i].ID] = authPolicies.Data[i].Secondary.RequireTotp
+	}
+
+	for i := range identities.Data {
+		policyID := identities.Data[i].AuthPolicyID
+		if policyID == "" {
+			policyID = defaultAuthPolicyID
+		}
+
+		policyIdentities[policyID]++
+
+		if identities.Data[i].IsMfaEnabled {
+			policyMfaEnrolled[policyID]++
+		} else if policyMfaRequired[policyID] {
+			policyMfaNotEnrolled[policyID]++
+		}
+	}
+
+	for i := range authPolicies.Data {
+		policy := authPolicies.Data[i]
+
+		if !c.filter.matches([]string{policy.Name}, nil, policy.Tags) {
+			continue
+		}
+
+		for method, allowed := range map[string]bool{
+			"cert":    policy.Primary.Cert.Allowed,
+			"ext_jwt": policy.Primary.ExtJwt.Allowed,
+			"updb":    policy.Primary.Updb.Allowed,
+		} {
+			ch <- prometheus.MustNewConstMetric(
+				prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, authPolicySpace,
+						"primary_method_allowed"),
+					"Auth Policy allows the primary authentication method.",
+					[]string{"name", "method"}, nil,
+				), prometheus.GaugeValue,
+				convertBool2Float(allowed),
+				policy.Name,
+				method,
+			)
+		}
+
+		for method, required := range map[string]bool{
+			"totp":    policy.Secondary.RequireTotp,
+			"ext_jwt": policy.Secondary.RequireExtJwtSigner != nil && *policy.Secondary.RequireExtJwtSigner != "",
+		} {
+			ch <- prometheus.MustNewConstMetric(
+				prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, authPolicySpace,
+						"secondary_method_required"),
+					"Auth Policy requires the secondary authentication method.",
+					[]string{"name", "method"}, nil,
+				), prometheus.GaugeValue,
+				convertBool2Float(required),
+				policy.Name,
+				method,
+			)
+		}
+
+		ch <- prometheus.MustNewConstMetric(
+			prometheus.NewDesc(
+				prometheus.BuildFQName(namespace, authPolicySpace,
+					"identities"),
+				"Number of default identities referencing the Auth Policy.",
+				[]string{"name"}, nil,
+			), prometheus.GaugeValue,
+			policyIdentities[policy.ID],
+			policy.Name,
+		)
+		ch <- prometheus.MustNewConstMetric(
+			prometheus.NewDesc(
+				prometheus.BuildFQName(namespace, authPolicySpace,
+					"mfa_enrolled_identities"),
+				"Number of default identities referencing the Auth Policy with MFA enrolled.",
+				[]string{"name"}, nil,
+			), prometheus.GaugeValue,
+			policyMfaEnrolled[policy.ID],
+			policy.Name,
+		)
+		ch <- prometheus.MustNewConstMetric(
+			prometheus.NewDesc(
+				prometheus.BuildFQName(namespace, authPolicySpace,
+					"mfa_not_enrolled_identities"),
+				"Number of default identities referencing the Auth Policy which requires MFA but without MFA enrolled.",
+				[]string{"name"}, nil,
+			), prometheus.GaugeValue,
+			policyMfaNotEnrolled[policy.ID],
+			policy.Name,
+		)
+	}
+
+	return nil
+}
+
+// RunAuthPolicies implements this command
+func (o *LoginOptions) RunAuthPolicies(filter string) (AuthPolicies, error) {
+	var (
+		limit                           = 50
+		offset                          = 0
+		policyStructTotal, policyStruct AuthPolicies
+		json                            = jsoniter.ConfigCompatibleWithStandardLibrary
+	)
+
+	jsonBytes, err := controllerAPICall(o, "edge_management", "/auth-policies", filter, limit, offset)
+	if err != nil {
+		return policyStructTotal, err
+	}
+
+	err = json.Unmarshal(jsonBytes, &policyStruct)
+	if err != nil {
+		return policyStructTotal, err
+	}
+
+	policyStructTotal.Data = append(policyStructTotal.Data, policyStruct.Data...)
+
+	totalPolicyCount := policyStruct.Meta.Pagination.TotalCount
+	level.Debug(o.Logger).Log("msg", "Total Ziti Auth Policies found", "count", totalPolicyCount)
+
+	for offset+limit < totalPolicyCount {
+		offset += limit
+
+		jsonBytes, err := controllerAPICall(o, "edge_management", "/auth-policies", filter, limit, offset)
+		if err != nil {
+			return policyStructTotal, err
+		}
+
+		err = json.Unmarshal(jsonBytes, &policyStruct)
+		if err != nil {
+			return policyStructTotal, err
+		}
+
+		policyStructTotal.Data = append(policyStructTotal.Data, policyStruct.Data...)
+	}
+
+	return policyStructTotal, err
+}
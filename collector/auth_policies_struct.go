@@ -0,0 +1,45 @@
+// Copyright 2023 enthus GmbH
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+// defaultAuthPolicyID is the auth policy applied to identities without an explicit one
+const defaultAuthPolicyID = "default"
+
+type AuthPolicies struct {
+	Data []AuthPolicy `json:"data"`
+	Meta MetaData     `json:"meta"`
+}
+
+// AuthPolicy represent the meaningful chracteristics of a Ziti Auth Policy
+// for this exporter
+type AuthPolicy struct {
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	Primary struct {
+		Cert struct {
+			Allowed bool `json:"allowed"`
+		} `json:"cert"`
+		ExtJwt struct {
+			Allowed bool `json:"allowed"`
+		} `json:"extJwt"`
+		Updb struct {
+			Allowed bool `json:"allowed"`
+		} `json:"updb"`
+	} `json:"primary"`
+	Secondary struct {
+		RequireExtJwtSigner *string `json:"requireExtJwtSigner"`
+		RequireTotp         bool    `json:"requireTotp"`
+	} `json:"secondary"`
+	Tags map[string]interface{} `json:"tags"`
+}
@@ -18,6 +18,7 @@ import (
 	"errors"
 	"fmt"
 	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -25,6 +26,7 @@ import (
 	"github.com/go-kit/log"
 	"github.com/go-kit/log/level"
 	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
 )
 
 // Namespace defines the common namespace to be used by all metrics.
@@ -43,6 +45,12 @@ var (
 		[]string{"collector"},
 		nil,
 	)
+	scrapeDuplicateSeriesDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "scrape", "collector_duplicate_series"),
+		"openziti_exporter: Number of duplicate series dropped from a collector scrape.",
+		[]string{"collector"},
+		nil,
+	)
 	loginErrorsDesc = prometheus.NewDesc(
 		prometheus.BuildFQName(namespace, "login", "errors_total"),
 		"Total number of login errors by type.",
@@ -60,6 +68,13 @@ const (
 	defaultDisabled = false
 )
 
+var (
+	legacyLabels = kingpin.Flag(
+		"collector.legacy-labels",
+		"Keep the descriptive labels on every value metric. Use --no-collector.legacy-labels to key value metrics by id and expose descriptive labels on the *_info metrics only.",
+	).Default("true").Bool()
+)
+
 var (
 	factories              = make(map[string]func(logger log.Logger, options *LoginOptions) (Collector, error))
 	initiatedCollectorsMtx = sync.Mutex{}
@@ -137,6 +152,14 @@ func NewOpenZitiCollector(logger log.Logger, options *LoginOptions, filters ...s
 		f[filter] = true
 	}
 
+	if err := validateTagLabels(); err != nil {
+		return nil, err
+	}
+
+	if err := validateVersionPolicy(); err != nil {
+		return nil, err
+	}
+
 	collectors := make(map[string]Collector)
 
 	initiatedCollectorsMtx.Lock()
@@ -167,6 +190,7 @@ func NewOpenZitiCollector(logger log.Logger, options *LoginOptions, filters ...s
 func (n OpenZitiCollector) Describe(ch chan<- *prometheus.Desc) {
 	ch <- scrapeDurationDesc
 	ch <- scrapeSuccessDesc
+	ch <- scrapeDuplicateSeriesDesc
 }
 
 // Collect implements the prometheus.Collector interface.
@@ -174,9 +198,11 @@ func (n OpenZitiCollector) Collect(ch chan<- prometheus.Metric) {
 	wg := sync.WaitGroup{}
 	wg.Add(len(n.Collectors))
 
+	dedup := newSeriesDeduplicator()
+
 	for name, c := range n.Collectors {
 		go func(name string, c Collector) {
-			execute(name, c, ch, n.logger)
+			execute(name, c, ch, dedup, n.logger)
 			wg.Done()
 		}(name, c)
 	}
@@ -198,11 +224,25 @@ func (n OpenZitiCollector) Collect(ch chan<- prometheus.Metric) {
 	wg.Wait()
 }
 
-func execute(name string, c Collector, ch chan<- prometheus.Metric, logger log.Logger) {
+func execute(name string, c Collector, ch chan<- prometheus.Metric, dedup *seriesDeduplicator, logger log.Logger) {
+	metrics := make(chan prometheus.Metric)
+	duplicates := make(chan float64)
+
+	go func() {
+		duplicates <- dedup.forward(metrics, ch)
+	}()
+
 	begin := time.Now()
-	err := c.Update(ch)
+	err := c.Update(metrics)
 	duration := time.Since(begin)
 
+	close(metrics)
+
+	duplicateSeries := <-duplicates
+	if duplicateSeries > 0 {
+		level.Warn(logger).Log("msg", "collector returned duplicate series, dropped", "name", name, "count", duplicateSeries)
+	}
+
 	var success float64
 
 	if err != nil {
@@ -220,6 +260,63 @@ func execute(name string, c Collector, ch chan<- prometheus.Metric, logger log.L
 	}
 	ch <- prometheus.MustNewConstMetric(scrapeDurationDesc, prometheus.GaugeValue, duration.Seconds(), name)
 	ch <- prometheus.MustNewConstMetric(scrapeSuccessDesc, prometheus.GaugeValue, success, name)
+	ch <- prometheus.MustNewConstMetric(scrapeDuplicateSeriesDesc, prometheus.GaugeValue, duplicateSeries, name)
+}
+
+// seriesDeduplicator drops the series already collected during a scrape, which would otherwise
+// fail the whole scrape with "collected before with the same name and label values".
+type seriesDeduplicator struct {
+	mtx  sync.Mutex
+	seen map[string]bool
+}
+
+func newSeriesDeduplicator() *seriesDeduplicator {
+	return &seriesDeduplicator{seen: make(map[string]bool)}
+}
+
+// forward sends the metrics of in not collected before to out, and returns the number of dropped duplicates
+func (d *seriesDeduplicator) forward(in <-chan prometheus.Metric, out chan<- prometheus.Metric) float64 {
+	var duplicates float64
+
+	for metric := range in {
+		if d.collectedBefore(metric) {
+			duplicates++
+			continue
+		}
+
+		out <- metric
+	}
+
+	return duplicates
+}
+
+// collectedBefore returns true if a series with the same name and label values was already collected
+func (d *seriesDeduplicator) collectedBefore(metric prometheus.Metric) bool {
+	var m dto.Metric
+
+	if err := metric.Write(&m); err != nil {
+		// let the registry report the invalid metric
+		return false
+	}
+
+	var key strings.Builder
+
+	key.WriteString(metric.Desc().String())
+
+	for _, label := range m.GetLabel() {
+		key.WriteString("\xff" + label.GetName() + "=" + label.GetValue())
+	}
+
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+
+	if d.seen[key.String()] {
+		return true
+	}
+
+	d.seen[key.String()] = true
+
+	return false
 }
 
 // Collector is the interface a collector has to implement.
@@ -27,7 +27,22 @@ import (
 
 // controllerAPICall will return a API call response
 // request.SetHeaderParam("zt-session", e.Token)
-func controllerAPICall(o *LoginOptions, api, endpoint string, limit, offset int) ([]byte, error) {
+func controllerAPICall(o *LoginOptions, api, endpoint, filter string, limit, offset int) ([]byte, error) {
+	params := map[string]string{
+		"limit":  strconv.Itoa(limit),
+		"offset": strconv.Itoa(offset),
+	}
+
+	// the filter is evaluated by the controller, using the Ziti filter language
+	if filter != "" {
+		params["filter"] = filter
+	}
+
+	return controllerAPIGet(o, api, endpoint, params)
+}
+
+// controllerAPIGet will return a API call response for the given query parameters
+func controllerAPIGet(o *LoginOptions, api, endpoint string, params map[string]string) ([]byte, error) {
 	client := util.NewClient()
 	timeout := o.Timeout
 	verbose := o.Verbose
@@ -51,8 +66,7 @@ func controllerAPICall(o *LoginOptions, api, endpoint string, limit, offset int)
 		SetTimeout(time.Duration(timeout)*time.Second).
 		SetDebug(verbose).
 		R().
-		SetQueryParam("limit", strconv.Itoa(limit)).
-		SetQueryParam("offset", strconv.Itoa(offset)).
+		SetQueryParams(params).
 		SetHeader("Content-Type", "application/json").
 		SetHeader("zt-session", o.Token).
 		Get(hostReady + endpoint)
@@ -0,0 +1,75 @@
+// Copyright 2023 enthus GmbH
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func TestSeriesDeduplicatorForward(t *testing.T) {
+	var (
+		desc = prometheus.NewDesc("openziti_router_online", "Router is currently online.", []string{"hostname"}, nil)
+		info = prometheus.NewDesc("openziti_router_info", "Router descriptive information.", []string{"hostname"}, nil)
+	)
+
+	metrics := []prometheus.Metric{
+		prometheus.MustNewConstMetric(desc, prometheus.GaugeValue, 1, "host-a"),
+		prometheus.MustNewConstMetric(desc, prometheus.GaugeValue, 0, "host-a"),
+		prometheus.MustNewConstMetric(desc, prometheus.GaugeValue, 1, "host-b"),
+		prometheus.MustNewConstMetric(info, prometheus.GaugeValue, 1, "host-a"),
+	}
+
+	dedup := newSeriesDeduplicator()
+
+	forward := func(metrics []prometheus.Metric) ([]prometheus.Metric, float64) {
+		in, out := make(chan prometheus.Metric), make(chan prometheus.Metric, len(metrics))
+
+		go func() {
+			for _, metric := range metrics {
+				in <- metric
+			}
+
+			close(in)
+		}()
+
+		duplicates := dedup.forward(in, out)
+		close(out)
+
+		var forwarded []prometheus.Metric
+		for metric := range out {
+			forwarded = append(forwarded, metric)
+		}
+
+		return forwarded, duplicates
+	}
+
+	forwarded, duplicates := forward(metrics)
+	if len(forwarded) != 3 || duplicates != 1 {
+		t.Fatalf("want 3 series forwarded and 1 duplicate, have %d and %v", len(forwarded), duplicates)
+	}
+
+	for i, want := range []prometheus.Metric{metrics[0], metrics[2], metrics[3]} {
+		if forwarded[i] != want {
+			t.Errorf("series %d: want %v, have %v", i, want.Desc(), forwarded[i].Desc())
+		}
+	}
+
+	// the series collected by another collector during the same scrape are duplicates too
+	forwarded, duplicates = forward(metrics[2:3])
+	if len(forwarded) != 0 || duplicates != 1 {
+		t.Errorf("want the series collected before dropped, have %d forwarded and %v duplicates", len(forwarded), duplicates)
+	}
+}
@@ -0,0 +1,312 @@
+// Copyright 2023 enthus GmbH
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/exp/slices"
+)
+
+type configsCollector struct {
+	logger  log.Logger
+	options *LoginOptions
+	filter  *entityFilter
+}
+
+const (
+	configSpace = "config"
+)
+
+func init() {
+	registerCollector("configs", defaultDisabled, newConfigsCollector)
+}
+
+// newConfigsCollector returns a new Collector exposing OpenZiti Configs metrics.
+func newConfigsCollector(logger log.Logger, options *LoginOptions) (Collector, error) {
+	filter, err := zitiConfigEntityFilter.entityFilter()
+	if err != nil {
+		return nil, err
+	}
+
+	return &configsCollector{
+		logger:  logger,
+		options: options,
+		filter:  filter,
+	}, nil
+}
+
+// Update pushes configs metrics onto ch
+func (c *configsCollector) Update(ch chan<- prometheus.Metric) (err error) {
+	// if not already logged, do the login.
+	if c.options == nil {
+		c.options, err = edgeAPILogin(c.logger)
+		if err != nil {
+			errString := fmt.Sprintf("%s", errors.Unwrap(err))
+			zitiLoginErrors[errString]++
+
+			return err
+		}
+
+		zitiLoginSuccess++
+	} else if c.options.Token == "" {
+		c.options, err = edgeAPILogin(c.logger)
+		if err != nil {
+			errString := fmt.Sprintf("%s", errors.Unwrap(err))
+			zitiLoginErrors[errString]++
+
+			return err
+		}
+
+		zitiLoginSuccess++
+	}
+
+	configTypes, err := c.options.RunConfigTypes()
+	if err != nil {
+		return err
+	}
+
+	configs, err := c.options.RunConfigs(*zitiConfigFilter)
+	if err != nil {
+		return err
+	}
+
+	services, err := c.options.RunServices("")
+	if err != nil {
+		return err
+	}
+
+	var (
+		json              = jsoniter.ConfigCompatibleWithStandardLibrary
+		configTypeNames   = make(map[string]string)
+		configTypeConfigs = make(map[string]float64)
+		intercepts        = make(map[string]InterceptV1)
+	)
+
+	for i := range configTypes.Data {
+		configTypeNames[configTypes.Data[i].ID] = configTypes.Data[i].Name
+	}
+
+	for i := range configs.Data {
+		if !c.filter.matches([]string{configs.Data[i].Name}, nil, configs.Data[i].Tags) {
+			continue
+		}
+
+		configTypeConfigs[configs.Data[i].ConfigTypeID]++
+
+		if configTypeNames[configs.Data[i].ConfigTypeID] == hostV1ConfigType {
+			var host HostV1
+			if err := json.Unmarshal(configs.Data[i].Data, &host); err != nil {
+				level.Warn(c.logger).Log("msg", "unable to parse host config", "name", configs.Data[i].Name, "err", err)
+				continue
+			}
+
+			ch <- prometheus.MustNewConstMetric(
+				prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, configSpace,
+						"host_info"),
+					"Protocol, address and port hosted by a host.v1 config, forwarded when taken from the intercepted connection.",
+					[]string{"config", "protocol", "address", "port"}, nil,
+				), prometheus.GaugeValue,
+				1,
+				append([]string{configs.Data[i].Name}, hostLabelValues(&host)...)...,
+			)
+
+			continue
+		}
+
+		if configTypeNames[configs.Data[i].ConfigTypeID] != interceptV1ConfigType {
+			continue
+		}
+
+		var intercept InterceptV1
+		if err := json.Unmarshal(configs.Data[i].Data, &intercept); err != nil {
+			level.Warn(c.logger).Log("msg", "unable to parse intercept config", "name", configs.Data[i].Name, "err", err)
+			continue
+		}
+
+		intercepts[configs.Data[i].ID] = intercept
+	}
+
+	for i := range configTypes.Data {
+		ch <- prometheus.MustNewConstMetric(
+			prometheus.NewDesc(
+				prometheus.BuildFQName(namespace, configSpace,
+					"type_configs"),
+				"Number of configs by config type.",
+				[]string{"config_type"}, nil,
+			), prometheus.GaugeValue,
+			configTypeConfigs[configTypes.Data[i].ID],
+			configTypes.Data[i].Name,
+		)
+	}
+
+	var conflicts float64
+
+	for i := range services.Data {
+		for j := i + 1; j < len(services.Data); j++ {
+			addresses := serviceInterceptOverlaps(&services.Data[i], &services.Data[j], intercepts)
+			if len(addresses) == 0 {
+				continue
+			}
+
+			conflicts++
+
+			for _, address := range addresses {
+				ch <- prometheus.MustNewConstMetric(
+					prometheus.NewDesc(
+						prometheus.BuildFQName(namespace, configSpace,
+							"intercept_conflict"),
+						"Intercept address of a service overlapping with the intercept of another service.",
+						[]string{"service", "conflicting_service", "address"}, nil,
+					), prometheus.GaugeValue,
+					1,
+					services.Data[i].Name,
+					services.Data[j].Name,
+					address,
+				)
+			}
+		}
+	}
+
+	ch <- prometheus.MustNewConstMetric(
+		prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, configSpace,
+				"intercept_conflicts"),
+			"Number of service pairs with overlapping intercepts.",
+			nil, nil,
+		), prometheus.GaugeValue,
+		conflicts,
+	)
+
+	return nil
+}
+
+// serviceInterceptOverlaps returns the intercept addresses of a overlapping with the intercepts of b
+func serviceInterceptOverlaps(a, b *Service, intercepts map[string]InterceptV1) []string {
+	var addresses []string
+
+	for _, configA := range a.Configs {
+		interceptA, ok := intercepts[configA]
+		if !ok {
+			continue
+		}
+
+		for _, configB := range b.Configs {
+			interceptB, ok := intercepts[configB]
+			if !ok {
+				continue
+			}
+
+			for _, address := range interceptOverlaps(&interceptA, &interceptB) {
+				if !slices.Contains(addresses, address) {
+					addresses = append(addresses, address)
+				}
+			}
+		}
+	}
+
+	return addresses
+}
+
+// RunConfigs implements this command
+func (o *LoginOptions) RunConfigs(filter string) (Configs, error) {
+	var (
+		limit                           = 50
+		offset                          = 0
+		configStructTotal, configStruct Configs
+		json                            = jsoniter.ConfigCompatibleWithStandardLibrary
+	)
+
+	jsonBytes, err := controllerAPICall(o, "edge_management", "/configs", filter, limit, offset)
+	if err != nil {
+		return configStructTotal, err
+	}
+
+	err = json.Unmarshal(jsonBytes, &configStruct)
+	if err != nil {
+		return configStructTotal, err
+	}
+
+	configStructTotal.Data = append(configStructTotal.Data, configStruct.Data...)
+
+	totalConfigCount := configStruct.Meta.Pagination.TotalCount
+	level.Debug(o.Logger).Log("msg", "Total Ziti Configs found", "count", totalConfigCount)
+
+	for offset+limit < totalConfigCount {
+		offset += limit
+
+		jsonBytes, err := controllerAPICall(o, "edge_management", "/configs", filter, limit, offset)
+		if err != nil {
+			return configStructTotal, err
+		}
+
+		err = json.Unmarshal(jsonBytes, &configStruct)
+		if err != nil {
+			return configStructTotal, err
+		}
+
+		configStructTotal.Data = append(configStructTotal.Data, configStruct.Data...)
+	}
+
+	return configStructTotal, err
+}
+
+// RunConfigTypes implements this command
+func (o *LoginOptions) RunConfigTypes() (ConfigTypes, error) {
+	var (
+		limit                                   = 50
+		offset                                  = 0
+		configTypeStructTotal, configTypeStruct ConfigTypes
+		json                                    = jsoniter.ConfigCompatibleWithStandardLibrary
+	)
+
+	jsonBytes, err := controllerAPICall(o, "edge_management", "/config-types", "", limit, offset)
+	if err != nil {
+		return configTypeStructTotal, err
+	}
+
+	err = json.Unmarshal(jsonBytes, &configTypeStruct)
+	if err != nil {
+		return configTypeStructTotal, err
+	}
+
+	configTypeStructTotal.Data = append(configTypeStructTotal.Data, configTypeStruct.Data...)
+
+	totalConfigTypeCount := configTypeStruct.Meta.Pagination.TotalCount
+	level.Debug(o.Logger).Log("msg", "Total Ziti Config Types found", "count", totalConfigTypeCount)
+
+	for offset+limit < totalConfigTypeCount {
+		offset += limit
+
+		jsonBytes, err := controllerAPICall(o, "edge_management", "/config-types", "", limit, offset)
+		if err != nil {
+			return configTypeStructTotal, err
+		}
+
+		err = json.Unmarshal(jsonBytes, &configTypeStruct)
+		if err != nil {
+			return configTypeStructTotal, err
+		}
+
+		configTypeStructTotal.Data = append(configTypeStructTotal.Data, configTypeStruct.Data...)
+	}
+
+	return configTypeStructTotal, err
+}
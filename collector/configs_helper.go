@@ -0,0 +1,126 @@
+// Copyright 2023 enthus GmbH
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"net/netip"
+	"strconv"
+	"strings"
+)
+
+// hostLabelValues returns the protocol, address and port hosted by a host.v1 config,
+// "forwarded" when the value is forwarded from the intercepted connection
+func hostLabelValues(host *HostV1) []string {
+	protocol, address, port := host.Protocol, host.Address, strconv.Itoa(host.Port)
+
+	if host.ForwardProtocol {
+		protocol = "forwarded"
+	}
+
+	if host.ForwardAddress {
+		address = "forwarded"
+	}
+
+	if host.ForwardPort {
+		port = "forwarded"
+	}
+
+	return []string{protocol, address, port}
+}
+
+// interceptOverlaps returns the addresses of a which b intercepts as well
+func interceptOverlaps(a, b *InterceptV1) []string {
+	var addresses []string
+
+	if !protocolsOverlap(a.Protocols, b.Protocols) || !portRangesOverlap(a.PortRanges, b.PortRanges) {
+		return addresses
+	}
+
+	for _, addrA := range a.Addresses {
+		for _, addrB := range b.Addresses {
+			if addressesOverlap(addrA, addrB) {
+				addresses = append(addresses, addrA)
+				break
+			}
+		}
+	}
+
+	return addresses
+}
+
+// protocolsOverlap returns true if both protocol lists share a protocol
+func protocolsOverlap(a, b []string) bool {
+	for i := range a {
+		for j := range b {
+			if strings.EqualFold(a[i], b[j]) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// portRangesOverlap returns true if both port range lists share a port
+func portRangesOverlap(a, b []PortRange) bool {
+	for i := range a {
+		for j := range b {
+			if a[i].Low <= b[j].High && b[j].Low <= a[i].High {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// addressesOverlap returns true if two intercept addresses (hostname, wildcard domain, IP or CIDR) overlap
+func addressesOverlap(a, b string) bool {
+	prefixA, okA := parseInterceptPrefix(a)
+	prefixB, okB := parseInterceptPrefix(b)
+
+	if okA || okB {
+		return okA && okB && prefixA.Overlaps(prefixB)
+	}
+
+	a = strings.TrimSuffix(strings.ToLower(a), ".")
+	b = strings.TrimSuffix(strings.ToLower(b), ".")
+
+	wildcardA := strings.HasPrefix(a, "*.")
+	wildcardB := strings.HasPrefix(b, "*.")
+
+	switch {
+	case wildcardA && wildcardB:
+		return strings.HasSuffix(a[1:], b[1:]) || strings.HasSuffix(b[1:], a[1:])
+	case wildcardA:
+		return strings.HasSuffix(b, a[1:])
+	case wildcardB:
+		return strings.HasSuffix(a, b[1:])
+	default:
+		return a == b
+	}
+}
+
+// parseInterceptPrefix returns the prefix of an IP or CIDR intercept address
+func parseInterceptPrefix(address string) (netip.Prefix, bool) {
+	if prefix, err := netip.ParsePrefix(address); err == nil {
+		return prefix.Masked(), true
+	}
+
+	if addr, err := netip.ParseAddr(address); err == nil {
+		return netip.PrefixFrom(addr, addr.BitLen()), true
+	}
+
+	return netip.Prefix{}, false
+}
@@ -0,0 +1,97 @@
+// Copyright 2023 enthus GmbH
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestAddressesOverlap(t *testing.T) {
+	for _, tc := range []struct {
+		a, b string
+		want bool
+	}{
+		{"app.example.com", "app.example.com", true},
+		{"App.Example.com.", "app.example.com", true},
+		{"app.example.com", "db.example.com", false},
+		{"*.example.com", "app.example.com", true},
+		{"*.example.com", "example.com", false},
+		{"*.example.com", "*.app.example.com", true},
+		{"*.example.com", "*.example.org", false},
+		{"10.0.0.0/24", "10.0.0.10", true},
+		{"10.0.0.0/24", "10.0.1.0/24", false},
+		{"10.0.0.0/16", "10.0.1.0/24", true},
+		{"10.0.0.1", "app.example.com", false},
+	} {
+		if have := addressesOverlap(tc.a, tc.b); have != tc.want {
+			t.Errorf("addressesOverlap(%q, %q): want %v, have %v", tc.a, tc.b, tc.want, have)
+		}
+	}
+}
+
+func TestInterceptOverlaps(t *testing.T) {
+	a := &InterceptV1{
+		Addresses:  []string{"app.example.com", "10.0.0.0/24"},
+		PortRanges: []PortRange{{Low: 80, High: 443}},
+		Protocols:  []string{"tcp"},
+	}
+
+	for _, tc := range []struct {
+		name string
+		b    *InterceptV1
+		want int
+	}{
+		{
+			name: "same address and port",
+			b:    &InterceptV1{Addresses: []string{"app.example.com"}, PortRanges: []PortRange{{Low: 443, High: 443}}, Protocols: []string{"TCP"}},
+			want: 1,
+		},
+		{
+			name: "disjoint ports",
+			b:    &InterceptV1{Addresses: []string{"app.example.com"}, PortRanges: []PortRange{{Low: 8080, High: 8080}}, Protocols: []string{"tcp"}},
+			want: 0,
+		},
+		{
+			name: "disjoint protocols",
+			b:    &InterceptV1{Addresses: []string{"app.example.com"}, PortRanges: []PortRange{{Low: 443, High: 443}}, Protocols: []string{"udp"}},
+			want: 0,
+		},
+		{
+			name: "wildcard and cidr",
+			b:    &InterceptV1{Addresses: []string{"*.example.com", "10.0.0.5"}, PortRanges: []PortRange{{Low: 1, High: 65535}}, Protocols: []string{"tcp", "udp"}},
+			want: 2,
+		},
+	} {
+		if have := interceptOverlaps(a, tc.b); len(have) != tc.want {
+			t.Errorf("%s: want %d overlapping addresses, have %v", tc.name, tc.want, have)
+		}
+	}
+}
+
+func TestHostLabelValues(t *testing.T) {
+	for _, tc := range []struct {
+		host HostV1
+		want []string
+	}{
+		{HostV1{Protocol: "tcp", Address: "localhost", Port: 8080}, []string{"tcp", "localhost", "8080"}},
+		{HostV1{Protocol: "tcp", ForwardAddress: true, ForwardPort: true}, []string{"tcp", "forwarded", "forwarded"}},
+		{HostV1{ForwardProtocol: true, Address: "10.0.0.1", Port: 443}, []string{"forwarded", "10.0.0.1", "443"}},
+	} {
+		have := hostLabelValues(&tc.host)
+		if strings.Join(have, ",") != strings.Join(tc.want, ",") {
+			t.Errorf("hostLabelValues(%+v): want %v, have %v", tc.host, tc.want, have)
+		}
+	}
+}
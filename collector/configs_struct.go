@@ -0,0 +1,71 @@
+// Copyright 2023 enthus GmbH
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import "encoding/json"
+
+const (
+	interceptV1ConfigType = "intercept.v1"
+	hostV1ConfigType      = "host.v1"
+)
+
+type Configs struct {
+	Data []Config `json:"data"`
+	Meta MetaData `json:"meta"`
+}
+
+// Config represent the meaningful chracteristics of a Ziti Config
+// for this exporter
+type Config struct {
+	ConfigTypeID string                 `json:"configTypeId"`
+	Data         json.RawMessage        `json:"data"`
+	ID           string                 `json:"id"`
+	Name         string                 `json:"name"`
+	Tags         map[string]interface{} `json:"tags"`
+}
+
+type ConfigTypes struct {
+	Data []ConfigType `json:"data"`
+	Meta MetaData     `json:"meta"`
+}
+
+// ConfigType represent the meaningful chracteristics of a Ziti Config Type
+// for this exporter
+type ConfigType struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// InterceptV1 represent the data of an intercept.v1 Ziti Config
+type InterceptV1 struct {
+	Addresses  []string    `json:"addresses"`
+	PortRanges []PortRange `json:"portRanges"`
+	Protocols  []string    `json:"protocols"`
+}
+
+// HostV1 represent the data of a host.v1 Ziti Config
+type HostV1 struct {
+	Address         string `json:"address"`
+	ForwardAddress  bool   `json:"forwardAddress"`
+	ForwardPort     bool   `json:"forwardPort"`
+	ForwardProtocol bool   `json:"forwardProtocol"`
+	Port            int    `json:"port"`
+	Protocol        string `json:"protocol"`
+}
+
+// PortRange represent an inclusive port range of a Ziti Config
+type PortRange struct {
+	High int `json:"high"`
+	Low  int `json:"low"`
+}
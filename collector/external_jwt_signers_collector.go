@@ -0,0 +1,243 @@
+// Copyright 2023 enthus GmbH
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/openziti/ziti/ziti/util"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type externalJWTSignersCollector struct {
+	logger  log.Logger
+	options *LoginOptions
+	filter  *entityFilter
+}
+
+const (
+	externalJWTSignerSpace = "external_jwt_signer"
+)
+
+func init() {
+	registerCollector("external_jwt_signers", defaultDisabled, newExternalJWTSignersCollector)
+}
+
+// newExternalJWTSignersCollector returns a new Collector exposing OpenZiti External JWT Signers metrics.
+func newExternalJWTSignersCollector(logger log.Logger, options *LoginOptions) (Collector, error) {
+	filter, err := zitiExternalJWTSignerEntityFilter.entityFilter()
+	if err != nil {
+		return nil, err
+	}
+
+	return &externalJWTSignersCollector{
+		logger:  logger,
+		options: options,
+		filter:  filter,
+	}, nil
+}
+
+// Update pushes external JWT signers metrics onto ch
+func (c *externalJWTSignersCollector) Update(ch chan<- prometheus.Metric) (err error) {
+	// if not already logged, do the login.
+	if c.options == nil {
+		c.options, err = edgeAPILogin(c.logger)
+		if err != nil {
+			errString := fmt.Sprintf("%s", errors.Unwrap(err))
+			zitiLoginErrors[errString]++
+
+			return err
+		}
+
+		zitiLoginSuccess++
+	} else if c.options.Token == "" {
+		c.options, err = edgeAPILogin(c.logger)
+		if err != nil {
+			errString := fmt.Sprintf("%s", errors.Unwrap(err))
+			zitiLoginErrors[errString]++
+
+			return err
+		}
+
+		zitiLoginSuccess++
+	}
+
+	signers, err := c.options.RunExternalJWTSigners(*zitiExternalJWTSignerFilter)
+	if err != nil {
+		return err
+	}
+
+	for i := range signers.Data {
+		if !c.filter.matches([]string{signers.Data[i].Name}, nil, signers.Data[i].Tags) {
+			continue
+		}
+
+		ch <- prometheus.MustNewConstMetric(
+			prometheus.NewDesc(
+				prometheus.BuildFQName(namespace, externalJWTSignerSpace,
+					"enabled"),
+				"External JWT Signer is currently enabled.",
+				[]string{"name", "issuer"}, nil,
+			), prometheus.GaugeValue,
+			convertBool2Float(signers.Data[i].Enabled),
+			signers.Data[i].Name,
+			signers.Data[i].Issuer,
+		)
+
+		if signers.Data[i].NotAfter != "" {
+			ch <- prometheus.MustNewConstMetric(
+				prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, externalJWTSignerSpace,
+						"cert_expiry_timestamp_seconds"),
+					"External JWT Signer certificate expiry timestamp.",
+					[]string{"name", "issuer"}, nil,
+				), prometheus.GaugeValue,
+				convertRFC33339toUnix(signers.Data[i].NotAfter),
+				signers.Data[i].Name,
+				signers.Data[i].Issuer,
+			)
+		}
+
+		if signers.Data[i].JwksEndpoint == "" {
+			continue
+		}
+
+		begin := time.Now()
+		keys, err := fetchJWKS(signers.Data[i].JwksEndpoint)
+		duration := time.Since(begin)
+
+		if err != nil {
+			level.Warn(c.logger).Log("msg", "unable to fetch JWKS", "name", signers.Data[i].Name, "endpoint", signers.Data[i].JwksEndpoint, "err", err)
+		}
+
+		ch <- prometheus.MustNewConstMetric(
+			prometheus.NewDesc(
+				prometheus.BuildFQName(namespace, externalJWTSignerSpace,
+					"jwks_up"),
+				"External JWT Signer JWKS endpoint is reachable and returned a valid key set.",
+				[]string{"name", "issuer", "endpoint"}, nil,
+			), prometheus.GaugeValue,
+			convertBool2Float(err == nil),
+			signers.Data[i].Name,
+			signers.Data[i].Issuer,
+			signers.Data[i].JwksEndpoint,
+		)
+		ch <- prometheus.MustNewConstMetric(
+			prometheus.NewDesc(
+				prometheus.BuildFQName(namespace, externalJWTSignerSpace,
+					"jwks_duration_seconds"),
+				"External JWT Signer JWKS endpoint fetch duration.",
+				[]string{"name", "issuer", "endpoint"}, nil,
+			), prometheus.GaugeValue,
+			duration.Seconds(),
+			signers.Data[i].Name,
+			signers.Data[i].Issuer,
+			signers.Data[i].JwksEndpoint,
+		)
+		ch <- prometheus.MustNewConstMetric(
+			prometheus.NewDesc(
+				prometheus.BuildFQName(namespace, externalJWTSignerSpace,
+					"jwks_keys"),
+				"Number of keys published by the External JWT Signer JWKS endpoint.",
+				[]string{"name", "issuer", "endpoint"}, nil,
+			), prometheus.GaugeValue,
+			float64(keys),
+			signers.Data[i].Name,
+			signers.Data[i].Issuer,
+			signers.Data[i].JwksEndpoint,
+		)
+	}
+
+	return nil
+}
+
+// RunExternalJWTSigners implements this command
+func (o *LoginOptions) RunExternalJWTSigners(filter string) (ExternalJWTSigners, error) {
+	var (
+		limit                           = 50
+		offset                          = 0
+		signerStructTotal, signerStruct ExternalJWTSigners
+		json                            = jsoniter.ConfigCompatibleWithStandardLibrary
+	)
+
+	jsonBytes, err := controllerAPICall(o, "edge_management", "/external-jwt-signers", filter, limit, offset)
+	if err != nil {
+		return signerStructTotal, err
+	}
+
+	err = json.Unmarshal(jsonBytes, &signerStruct)
+	if err != nil {
+		return signerStructTotal, err
+	}
+
+	signerStructTotal.Data = append(signerStructTotal.Data, signerStruct.Data...)
+
+	totalSignerCount := signerStruct.Meta.Pagination.TotalCount
+	level.Debug(o.Logger).Log("msg", "Total Ziti External JWT Signers found", "count", totalSignerCount)
+
+	for offset+limit < totalSignerCount {
+		offset += limit
+
+		jsonBytes, err := controllerAPICall(o, "edge_management", "/external-jwt-signers", filter, limit, offset)
+		if err != nil {
+			return signerStructTotal, err
+		}
+
+		err = json.Unmarshal(jsonBytes, &signerStruct)
+		if err != nil {
+			return signerStructTotal, err
+		}
+
+		signerStructTotal.Data = append(signerStructTotal.Data, signerStruct.Data...)
+	}
+
+	return signerStructTotal, err
+}
+
+// fetchJWKS returns the number of keys published by a JWKS endpoint
+func fetchJWKS(endpoint string) (int, error) {
+	var (
+		keySet JSONWebKeySet
+		json   = jsoniter.ConfigCompatibleWithStandardLibrary
+	)
+
+	resp, err := util.NewClient().
+		SetTimeout(*zitiJwksTimeout).
+		R().
+		SetHeader("Accept", "application/json").
+		Get(endpoint)
+	if err != nil {
+		return 0, fmt.Errorf("unable to reach %v. Error: %v", endpoint, err)
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return 0, fmt.Errorf("unable to fetch %v. Status code: %v", endpoint, resp.Status())
+	}
+
+	if err := json.Unmarshal(resp.Body(), &keySet); err != nil {
+		return 0, fmt.Errorf("invalid key set returned by %v: %w", endpoint, err)
+	}
+
+	if len(keySet.Keys) == 0 {
+		return 0, fmt.Errorf("no keys returned by %v", endpoint)
+	}
+
+	return len(keySet.Keys), nil
+}
@@ -0,0 +1,28 @@
+// Copyright 2023 enthus GmbH
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"github.com/alecthomas/kingpin/v2"
+)
+
+var (
+	zitiJwksTimeout = kingpin.Flag(
+		"collector.external_jwt_signers.jwks.timeout", "Timeout for fetching the JWKS endpoint of an External JWT Signer.",
+	).Default("5s").Duration()
+	zitiExternalJWTSignerFilter = kingpin.Flag(
+		"collector.external_jwt_signers.filter", "Ziti filter expression evaluated by the controller when listing external JWT signers, e.g. 'enabled = true'.",
+	).Default("").String()
+	zitiExternalJWTSignerEntityFilter = registerEntityFilterFlags("external_jwt_signers", "external JWT signers")
+)
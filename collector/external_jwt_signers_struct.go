@@ -0,0 +1,39 @@
+// Copyright 2023 enthus GmbH
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+type ExternalJWTSigners struct {
+	Data []ExternalJWTSigner `json:"data"`
+	Meta MetaData            `json:"meta"`
+}
+
+// ExternalJWTSigner represent the meaningful chracteristics of a Ziti External JWT Signer
+// for this exporter
+type ExternalJWTSigner struct {
+	CertPem      string                 `json:"certPem"`
+	Enabled      bool                   `json:"enabled"`
+	Issuer       string                 `json:"issuer"`
+	JwksEndpoint string                 `json:"jwksEndpoint"`
+	Kid          string                 `json:"kid"`
+	Name         string                 `json:"name"`
+	NotAfter     string                 `json:"notAfter"`
+	Tags         map[string]interface{} `json:"tags"`
+}
+
+// JSONWebKeySet represent the keys published by an External JWT Signer JWKS endpoint
+type JSONWebKeySet struct {
+	Keys []struct {
+		Kid string `json:"kid"`
+	} `json:"keys"`
+}
@@ -16,12 +16,15 @@ package collector
 import (
 	"errors"
 	"fmt"
+	"sync"
+	"time"
 
 	jsoniter "github.com/json-iterator/go"
 
 	"github.com/go-kit/log"
 	"github.com/go-kit/log/level"
 	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/exp/slices"
 )
 
 const (
@@ -32,6 +35,11 @@ const (
 type fabricLinksCollector struct {
 	logger  log.Logger
 	options *LoginOptions
+	filter  *entityFilter
+
+	// links tracked across scrapes, by router pair
+	historyMtx sync.Mutex
+	history    map[fabricLinkPair]*fabricLinkHistory
 }
 
 func init() {
@@ -40,9 +48,16 @@ func init() {
 
 // newFabricLinksCollector returns a new Collector exposing OpenZiti fabric links metrics.
 func newFabricLinksCollector(logger log.Logger, options *LoginOptions) (Collector, error) {
+	filter, err := zitiFabricLinkEntityFilter.entityFilter()
+	if err != nil {
+		return nil, err
+	}
+
 	return &fabricLinksCollector{
 		logger:  logger,
 		options: options,
+		filter:  filter,
+		history: make(map[fabricLinkPair]*fabricLinkHistory),
 	}, nil
 }
 
@@ -71,12 +86,71 @@ func (c *fabricLinksCollector) Update(ch chan<- prometheus.Metric) (err error) {
 		zitiLoginSuccess++
 	}
 
-	fabricLinks, err := c.options.RunFabricLinks()
+	fabricLinks, err := c.options.RunFabricLinks(*zitiFabricLinkFilter)
 	if err != nil {
 		return err
 	}
 
+	var (
+		now              = time.Now()
+		seen             = make(map[string]fabricLinkPair)
+		routerAggregates = make(map[fabricLinkRouterAggregate]float64)
+	)
+
 	for i := range fabricLinks.Data {
+		if !c.filter.matches([]string{fabricLinks.Data[i].SourceRouter.Name, fabricLinks.Data[i].DestRouter.Name}, nil, fabricLinks.Data[i].Tags) {
+			continue
+		}
+
+		seen[fabricLinks.Data[i].ID] = fabricLinkPair{source: fabricLinks.Data[i].SourceRouter.Name, destination: fabricLinks.Data[i].DestRouter.Name}
+
+		state := fabricLinkState(&fabricLinks.Data[i])
+
+		for _, router := range []string{fabricLinks.Data[i].SourceRouter.Name, fabricLinks.Data[i].DestRouter.Name} {
+			routerAggregates[fabricLinkRouterAggregate{
+				router:   router,
+				state:    state,
+				protocol: fabricLinks.Data[i].Protocol,
+			}]++
+		}
+
+		ch <- prometheus.MustNewConstMetric(
+			prometheus.NewDesc(
+				prometheus.BuildFQName(namespace,
+					fabricLinksSpace, "info"),
+				"Fabric Link descriptive information.",
+				append([]string{"destination", "source", "id", "protocol"}, tagLabelNames()...), nil,
+			), prometheus.GaugeValue,
+			1,
+			append([]string{
+				fabricLinks.Data[i].DestRouter.Name,
+				fabricLinks.Data[i].SourceRouter.Name,
+				fabricLinks.Data[i].ID,
+				fabricLinks.Data[i].Protocol,
+			}, tagLabelValues(fabricLinks.Data[i].Tags)...)...,
+		)
+
+		states := fabricLinkStates
+		if !slices.Contains(states, state) {
+			states = append(slices.Clone(states), state)
+		}
+
+		for _, linkState := range states {
+			ch <- prometheus.MustNewConstMetric(
+				prometheus.NewDesc(
+					prometheus.BuildFQName(namespace,
+						fabricLinksSpace, "state"),
+					"Fabric Link state, 1 for the current state.",
+					[]string{"destination", "source", "id", "state"}, nil,
+				), prometheus.GaugeValue,
+				convertBool2Float(linkState == state),
+				fabricLinks.Data[i].DestRouter.Name,
+				fabricLinks.Data[i].SourceRouter.Name,
+				fabricLinks.Data[i].ID,
+				linkState,
+			)
+		}
+
 		ch <- prometheus.MustNewConstMetric(
 			prometheus.NewDesc(
 				prometheus.BuildFQName(namespace,
@@ -140,11 +214,189 @@ func (c *fabricLinksCollector) Update(ch chan<- prometheus.Metric) (err error) {
 		)
 	}
 
+	c.collectHistory(ch, seen, now)
+
+	if *zitiFabricLinkMesh {
+		c.updateMesh(ch, &fabricLinks)
+	}
+
+	for aggregate, count := range routerAggregates {
+		ch <- prometheus.MustNewConstMetric(
+			prometheus.NewDesc(
+				prometheus.BuildFQName(namespace,
+					fabricLinksSpace, "by_router"),
+				"Number of Fabric Links by router, state and protocol.",
+				[]string{"router", "state", "protocol"}, nil,
+			), prometheus.GaugeValue,
+			count,
+			aggregate.router,
+			aggregate.state,
+			aggregate.protocol,
+		)
+	}
+
 	return nil
 }
 
+// updateMesh pushes the expected and missing fabric links metrics onto ch,
+// nothing when the fabric routers are not available
+func (c *fabricLinksCollector) updateMesh(ch chan<- prometheus.Metric, fabricLinks *FabricLinks) {
+	fabricRouters, err := c.options.RunFabricRouters()
+	if err != nil {
+		level.Warn(c.logger).Log("msg", "unable to list the fabric routers, fabric links mesh not exposed", "err", err)
+		return
+	}
+
+	var (
+		routers     []FabricRouter
+		established = make(map[fabricLinkPair]bool)
+		expected    = make(map[string]float64)
+		actual      = make(map[string]float64)
+	)
+
+	for i := range fabricRouters.Data {
+		if c.filter.matches([]string{fabricRouters.Data[i].Name}, nil, fabricRouters.Data[i].Tags) {
+			routers = append(routers, fabricRouters.Data[i])
+			expected[fabricRouters.Data[i].Name] = 0
+			actual[fabricRouters.Data[i].Name] = 0
+		}
+	}
+
+	for i := range fabricLinks.Data {
+		// pending, dialing or failed links are not established
+		if fabricLinkState(&fabricLinks.Data[i]) == "connected" {
+			established[fabricLinkPair{source: fabricLinks.Data[i].SourceRouter.Name, destination: fabricLinks.Data[i].DestRouter.Name}] = true
+		}
+	}
+
+	for _, pair := range expectedFabricLinks(routers) {
+		linked := established[pair] || established[fabricLinkPair{source: pair.destination, destination: pair.source}]
+
+		for _, router := range []string{pair.source, pair.destination} {
+			expected[router]++
+
+			if linked {
+				actual[router]++
+			}
+		}
+
+		ch <- prometheus.MustNewConstMetric(
+			prometheus.NewDesc(
+				prometheus.BuildFQName(namespace,
+					fabricLinksSpace, "missing"),
+				"Expected Fabric Link missing between the routers. (1: missing, 0: established)",
+				[]string{"source", "destination"}, nil,
+			), prometheus.GaugeValue,
+			convertBool2Float(!linked),
+			pair.source,
+			pair.destination,
+		)
+	}
+
+	for router := range expected {
+		ch <- prometheus.MustNewConstMetric(
+			prometheus.NewDesc(
+				prometheus.BuildFQName(namespace,
+					fabricLinksSpace, "expected_by_router"),
+				"Number of Fabric Links expected for the router.",
+				[]string{"router"}, nil,
+			), prometheus.GaugeValue,
+			expected[router],
+			router,
+		)
+		ch <- prometheus.MustNewConstMetric(
+			prometheus.NewDesc(
+				prometheus.BuildFQName(namespace,
+					fabricLinksSpace, "established_by_router"),
+				"Number of expected Fabric Links established for the router.",
+				[]string{"router"}, nil,
+			), prometheus.GaugeValue,
+			actual[router],
+			router,
+		)
+	}
+}
+
+// collectHistory records the links seen during a scrape and pushes the links history metrics onto ch
+func (c *fabricLinksCollector) collectHistory(ch chan<- prometheus.Metric, seen map[string]fabricLinkPair, now time.Time) {
+	c.historyMtx.Lock()
+	defer c.historyMtx.Unlock()
+
+	c.updateHistory(seen, now)
+
+	for pair, history := range c.history {
+		ch <- prometheus.MustNewConstMetric(
+			prometheus.NewDesc(
+				prometheus.BuildFQName(namespace,
+					fabricLinksSpace, "reestablished_total"),
+				"Number of Fabric Links re-established between the routers, in either direction, since the exporter start. (router_a sorts before router_b)",
+				[]string{"router_a", "router_b"}, nil,
+			), prometheus.CounterValue,
+			history.reestablished,
+			pair.source,
+			pair.destination,
+		)
+
+		for id, link := range history.links {
+			ch <- prometheus.MustNewConstMetric(
+				prometheus.NewDesc(
+					prometheus.BuildFQName(namespace,
+						fabricLinksSpace, "age_seconds"),
+					"Fabric Link age, since it was first seen by the exporter.",
+					[]string{"destination", "source", "id"}, nil,
+				), prometheus.GaugeValue,
+				now.Sub(link.firstSeen).Seconds(),
+				link.pair.destination,
+				link.pair.source,
+				id,
+			)
+		}
+	}
+}
+
+// updateHistory records the links seen during a scrape, by link id. A new link between a router pair,
+// in either direction, is counted as re-established when it replaces a link gone since, not when it is added
+// in parallel. The router pairs without links since the history expiry are forgotten.
+func (c *fabricLinksCollector) updateHistory(seen map[string]fabricLinkPair, now time.Time) {
+	for key, history := range c.history {
+		for id := range history.links {
+			if _, ok := seen[id]; !ok {
+				delete(history.links, id)
+				history.lost++
+			}
+		}
+
+		if len(history.links) == 0 && now.Sub(history.lastSeen) > *zitiFabricLinkHistoryExpiry {
+			delete(c.history, key)
+		}
+	}
+
+	for id, pair := range seen {
+		key := newFabricLinkHistoryKey(pair)
+
+		history, ok := c.history[key]
+		if !ok {
+			history = &fabricLinkHistory{links: make(map[string]fabricLinkRecord)}
+			c.history[key] = history
+		}
+
+		history.lastSeen = now
+
+		if _, ok := history.links[id]; ok {
+			continue
+		}
+
+		if history.lost > 0 {
+			history.lost--
+			history.reestablished++
+		}
+
+		history.links[id] = fabricLinkRecord{pair: pair, firstSeen: now}
+	}
+}
+
 // RunFabricLinks implements this command
-func (o *LoginOptions) RunFabricLinks() (FabricLinks, error) {
+func (o *LoginOptions) RunFabricLinks(filter string) (FabricLinks, error) {
 	var (
 		limit                                     = 50
 		offset                                    = 0
@@ -152,7 +404,7 @@ func (o *LoginOptions) RunFabricLinks() (FabricLinks, error) {
 		json                                      = jsoniter.ConfigCompatibleWithStandardLibrary
 	)
 
-	jsonBytes, err := controllerAPICall(o, "fabric", "/links", limit, offset)
+	jsonBytes, err := controllerAPICall(o, "fabric", "/links", filter, limit, offset)
 	if err != nil {
 		return fabricLinksStructTotal, err
 	}
@@ -170,7 +422,7 @@ func (o *LoginOptions) RunFabricLinks() (FabricLinks, error) {
 	for offset+limit < totalFabricLinksCount {
 		offset += limit
 
-		jsonBytes, err := controllerAPICall(o, "fabric", "/links", limit, offset)
+		jsonBytes, err := controllerAPICall(o, "fabric", "/links", filter, limit, offset)
 		if err != nil {
 			return fabricLinksStructTotal, err
 		}
@@ -185,3 +437,46 @@ func (o *LoginOptions) RunFabricLinks() (FabricLinks, error) {
 
 	return fabricLinksStructTotal, err
 }
+
+// RunFabricRouters implements this command
+func (o *LoginOptions) RunFabricRouters() (FabricRouters, error) {
+	var (
+		limit                                         = 50
+		offset                                        = 0
+		fabricRoutersStructTotal, fabricRoutersStruct FabricRouters
+		json                                          = jsoniter.ConfigCompatibleWithStandardLibrary
+	)
+
+	jsonBytes, err := controllerAPICall(o, "fabric", "/routers", "", limit, offset)
+	if err != nil {
+		return fabricRoutersStructTotal, err
+	}
+
+	err = json.Unmarshal(jsonBytes, &fabricRoutersStruct)
+	if err != nil {
+		return fabricRoutersStructTotal, err
+	}
+
+	fabricRoutersStructTotal.Data = append(fabricRoutersStructTotal.Data, fabricRoutersStruct.Data...)
+
+	totalFabricRoutersCount := fabricRoutersStruct.Meta.Pagination.TotalCount
+	level.Debug(o.Logger).Log("msg", "Total Ziti Fabric Routers found", "count", totalFabricRoutersCount)
+
+	for offset+limit < totalFabricRoutersCount {
+		offset += limit
+
+		jsonBytes, err := controllerAPICall(o, "fabric", "/routers", "", limit, offset)
+		if err != nil {
+			return fabricRoutersStructTotal, err
+		}
+
+		err = json.Unmarshal(jsonBytes, &fabricRoutersStruct)
+		if err != nil {
+			return fabricRoutersStructTotal, err
+		}
+
+		fabricRoutersStructTotal.Data = append(fabricRoutersStructTotal.Data, fabricRoutersStruct.Data...)
+	}
+
+	return fabricRoutersStructTotal, err
+}
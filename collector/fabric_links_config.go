@@ -0,0 +1,34 @@
+// Copyright 2023 enthus GmbH
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"github.com/alecthomas/kingpin/v2"
+)
+
+var (
+	zitiFabricLinkFilter = kingpin.Flag(
+		"collector.fabric_links.filter", "Ziti filter expression evaluated by the controller when listing fabric links, e.g. 'protocol = \"tls\"'.",
+	).Default("").String()
+	zitiFabricLinkEntityFilter  = registerEntityFilterFlags("fabric_links", "fabric links source or destination router")
+	zitiFabricLinkHistoryExpiry = kingpin.Flag(
+		"collector.fabric_links.history.expiry", "Duration after which the re-established links count of a router pair without links is forgotten.",
+	).Default("24h").Duration()
+	zitiFabricLinkMesh = kingpin.Flag(
+		"collector.fabric_links.mesh", "Compare the fabric links with the expected mesh of the connected fabric routers with link listeners.",
+	).Default("false").Bool()
+	zitiFabricLinkMeshGroupsTag = kingpin.Flag(
+		"collector.fabric_links.mesh.groups-tag", "Fabric router tag holding the comma-separated link groups of the router, routers are only expected to be linked when sharing a group. Empty to expect a full mesh.",
+	).Default("").String()
+)
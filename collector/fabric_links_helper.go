@@ -0,0 +1,121 @@
+// Copyright 2023 enthus GmbH
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// fabricLinkRouterAggregate is the key of the Fabric Link counts per router
+type fabricLinkRouterAggregate struct {
+	router   string
+	state    string
+	protocol string
+}
+
+// fabricLinkState returns the normalized state of a Fabric Link
+func fabricLinkState(link *FabricLink) string {
+	if link.State == "" {
+		return "unknown"
+	}
+
+	return strings.ToLower(link.State)
+}
+
+// fabricLinkPair is a source and destination routers pair
+type fabricLinkPair struct {
+	source      string
+	destination string
+}
+
+// newFabricLinkHistoryKey returns the router pair of a Fabric Link regardless of its direction
+func newFabricLinkHistoryKey(pair fabricLinkPair) fabricLinkPair {
+	if pair.destination < pair.source {
+		return fabricLinkPair{source: pair.destination, destination: pair.source}
+	}
+
+	return pair
+}
+
+// fabricLinkHistory tracks the Fabric Links of a router pair across scrapes
+type fabricLinkHistory struct {
+	links         map[string]fabricLinkRecord // by link id
+	lost          int                         // links gone and not replaced yet
+	lastSeen      time.Time
+	reestablished float64
+}
+
+// fabricLinkRecord is a Fabric Link tracked across scrapes
+type fabricLinkRecord struct {
+	pair      fabricLinkPair
+	firstSeen time.Time
+}
+
+// fabricRouterGroups returns the link groups of a Fabric Router
+func fabricRouterGroups(router *FabricRouter) []string {
+	if *zitiFabricLinkMeshGroupsTag == "" {
+		return []string{"default"}
+	}
+
+	value, ok := router.Tags[*zitiFabricLinkMeshGroupsTag]
+	if !ok || value == nil {
+		return nil
+	}
+
+	return splitList(fmt.Sprint(value))
+}
+
+// fabricRoutersShareGroup returns true if both Fabric Routers have a link group in common
+func fabricRoutersShareGroup(a, b *FabricRouter) bool {
+	groups := fabricRouterGroups(b)
+
+	for _, group := range fabricRouterGroups(a) {
+		for i := range groups {
+			if group == groups[i] {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// expectedFabricLinks returns the router pairs expected to be linked: connected and enabled routers sharing
+// a link group, at least one of them with a link listener. The source is the router dialing the listener.
+func expectedFabricLinks(routers []FabricRouter) []fabricLinkPair {
+	var pairs []fabricLinkPair
+
+	for i := range routers {
+		if !routers[i].Connected || routers[i].Disabled {
+			continue
+		}
+
+		for j := i + 1; j < len(routers); j++ {
+			if !routers[j].Connected || routers[j].Disabled || !fabricRoutersShareGroup(&routers[i], &routers[j]) {
+				continue
+			}
+
+			switch {
+			case len(routers[j].ListenerAddresses) > 0:
+				pairs = append(pairs, fabricLinkPair{source: routers[i].Name, destination: routers[j].Name})
+			case len(routers[i].ListenerAddresses) > 0:
+				pairs = append(pairs, fabricLinkPair{source: routers[j].Name, destination: routers[i].Name})
+			}
+		}
+	}
+
+	return pairs
+}
@@ -0,0 +1,114 @@
+// Copyright 2023 enthus GmbH
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"testing"
+	"time"
+)
+
+func TestExpectedFabricLinks(t *testing.T) {
+	listener := func(name string) FabricRouter {
+		return FabricRouter{
+			Connected:         true,
+			Name:              name,
+			ListenerAddresses: []FabricRouterListenerAddress{{Address: "tls:" + name + ":6000", Protocol: "tls"}},
+		}
+	}
+
+	routers := []FabricRouter{
+		listener("public-a"),
+		listener("public-b"),
+		{Connected: true, Name: "private"},
+		{Connected: true, Name: "private-other"},
+		{Connected: false, Name: "offline"},
+	}
+
+	want := []fabricLinkPair{
+		{source: "public-a", destination: "public-b"},
+		{source: "private", destination: "public-a"},
+		{source: "private-other", destination: "public-a"},
+		{source: "private", destination: "public-b"},
+		{source: "private-other", destination: "public-b"},
+	}
+
+	have := expectedFabricLinks(routers)
+	if len(have) != len(want) {
+		t.Fatalf("expectedFabricLinks: want %v, have %v", want, have)
+	}
+
+	for _, pair := range want {
+		found := false
+
+		for i := range have {
+			if have[i] == pair {
+				found = true
+			}
+		}
+
+		if !found {
+			t.Errorf("expectedFabricLinks: missing %v in %v", pair, have)
+		}
+	}
+}
+
+func TestFabricLinksUpdateHistory(t *testing.T) {
+	var (
+		c       = &fabricLinksCollector{history: make(map[fabricLinkPair]*fabricLinkHistory)}
+		ab      = fabricLinkPair{source: "router-a", destination: "router-b"}
+		ba      = fabricLinkPair{source: "router-b", destination: "router-a"}
+		start   = time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+		scrapes = []struct {
+			name              string
+			seen              map[string]fabricLinkPair
+			wantLinks         int
+			wantReestablished float64
+		}{
+			{"new pair", map[string]fabricLinkPair{"link-1": ab}, 1, 0},
+			{"same link", map[string]fabricLinkPair{"link-1": ab}, 1, 0},
+			{"parallel link", map[string]fabricLinkPair{"link-1": ab, "link-2": ab}, 2, 0},
+			{"re-dial in the other direction", map[string]fabricLinkPair{"link-1": ab, "link-3": ba}, 2, 1},
+			{"link removed", map[string]fabricLinkPair{"link-3": ba}, 1, 1},
+			{"re-dial after the removal", map[string]fabricLinkPair{"link-3": ba, "link-4": ab}, 2, 2},
+			{"all links removed", map[string]fabricLinkPair{}, 0, 2},
+		}
+	)
+
+	previousExpiry := *zitiFabricLinkHistoryExpiry
+	*zitiFabricLinkHistoryExpiry = 24 * time.Hour
+
+	t.Cleanup(func() {
+		*zitiFabricLinkHistoryExpiry = previousExpiry
+	})
+
+	for i, scrape := range scrapes {
+		c.updateHistory(scrape.seen, start.Add(time.Duration(i)*time.Minute))
+
+		history, ok := c.history[ab]
+		if !ok {
+			t.Fatalf("%s: router pair missing from the history", scrape.name)
+		}
+
+		if len(history.links) != scrape.wantLinks || history.reestablished != scrape.wantReestablished {
+			t.Errorf("%s: want %d links and %v re-established, have %d and %v",
+				scrape.name, scrape.wantLinks, scrape.wantReestablished, len(history.links), history.reestablished)
+		}
+	}
+
+	c.updateHistory(map[string]fabricLinkPair{}, start.Add(*zitiFabricLinkHistoryExpiry+time.Hour))
+
+	if _, ok := c.history[ab]; ok {
+		t.Errorf("router pair without links not forgotten after the history expiry")
+	}
+}
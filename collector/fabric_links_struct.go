@@ -13,6 +13,9 @@
 
 package collector
 
+// fabricLinkStates lists the known Fabric Link states
+var fabricLinkStates = []string{"pending", "dialing", "connected", "failed"}
+
 type FabricLinks struct {
 	Data []FabricLink `json:"data"`
 	Meta MetaData     `json:"meta"`
@@ -33,6 +36,29 @@ type FabricLink struct {
 	SourceRouter  struct {
 		Name string `json:"name"`
 	} `json:"sourceRouter"`
-	State      string  `json:"state"`
-	StaticCost float64 `json:"staticCost"`
+	State      string                 `json:"state"`
+	StaticCost float64                `json:"staticCost"`
+	Tags       map[string]interface{} `json:"tags"`
+}
+
+type FabricRouters struct {
+	Data []FabricRouter `json:"data"`
+	Meta MetaData       `json:"meta"`
+}
+
+// FabricRouter represent the meaningful chracteristics of a Ziti Fabric Router
+// for this exporter
+type FabricRouter struct {
+	Connected         bool                          `json:"connected"`
+	Disabled          bool                          `json:"disabled"`
+	ID                string                        `json:"id"`
+	ListenerAddresses []FabricRouterListenerAddress `json:"listenerAddresses"`
+	Name              string                        `json:"name"`
+	Tags              map[string]interface{}        `json:"tags"`
+}
+
+// FabricRouterListenerAddress represent a link listener address of a Ziti Fabric Router
+type FabricRouterListenerAddress struct {
+	Address  string `json:"address"`
+	Protocol string `json:"protocol"`
 }
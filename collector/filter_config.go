@@ -0,0 +1,107 @@
+// Copyright 2023 enthus GmbH
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/alecthomas/kingpin/v2"
+)
+
+// entityFilterFlags are the include/exclude command-line flags of a collector
+type entityFilterFlags struct {
+	nameInclude  *string
+	nameExclude  *string
+	roleAttrAny  *string
+	roleAttrAll  *string
+	roleAttrNone *string
+	tags         *string
+}
+
+// registerEntityFilterFlags registers the include/exclude flags of a collector for the given entities.
+func registerEntityFilterFlags(collector, entities string) *entityFilterFlags {
+	prefix := "collector." + collector + "."
+
+	return &entityFilterFlags{
+		nameInclude: kingpin.Flag(
+			prefix+"name-include", fmt.Sprintf("Regexp of %s names to include (anchored).", entities),
+		).Default("").String(),
+		nameExclude: kingpin.Flag(
+			prefix+"name-exclude", fmt.Sprintf("Regexp of %s names to exclude (anchored).", entities),
+		).Default("").String(),
+		roleAttrAny: kingpin.Flag(
+			prefix+"role-attributes.any", fmt.Sprintf("Comma-separated role attributes, %s carrying any of them are included.", entities),
+		).Default("").String(),
+		roleAttrAll: kingpin.Flag(
+			prefix+"role-attributes.all", fmt.Sprintf("Comma-separated role attributes, %s carrying all of them are included.", entities),
+		).Default("").String(),
+		roleAttrNone: kingpin.Flag(
+			prefix+"role-attributes.none", fmt.Sprintf("Comma-separated role attributes, %s carrying any of them are excluded.", entities),
+		).Default("").String(),
+		tags: kingpin.Flag(
+			prefix+"tags", fmt.Sprintf("Comma-separated key=value tags, %s carrying all of them are included.", entities),
+		).Default("").String(),
+	}
+}
+
+// entityFilter returns the entity filter configured by the command-line flags.
+func (f *entityFilterFlags) entityFilter() (*entityFilter, error) {
+	var (
+		filter = &entityFilter{
+			roleAttrAny:  splitList(*f.roleAttrAny),
+			roleAttrAll:  splitList(*f.roleAttrAll),
+			roleAttrNone: splitList(*f.roleAttrNone),
+			tags:         make(map[string]string),
+		}
+		err error
+	)
+
+	if *f.nameInclude != "" {
+		if filter.nameInclude, err = regexp.Compile("^(?:" + *f.nameInclude + ")$"); err != nil {
+			return nil, fmt.Errorf("invalid name include regexp %q: %w", *f.nameInclude, err)
+		}
+	}
+
+	if *f.nameExclude != "" {
+		if filter.nameExclude, err = regexp.Compile("^(?:" + *f.nameExclude + ")$"); err != nil {
+			return nil, fmt.Errorf("invalid name exclude regexp %q: %w", *f.nameExclude, err)
+		}
+	}
+
+	for _, tag := range splitList(*f.tags) {
+		key, value, ok := strings.Cut(tag, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid tag %q, expected key=value", tag)
+		}
+
+		filter.tags[key] = value
+	}
+
+	return filter, nil
+}
+
+// splitList returns the non-empty trimmed values of a comma-separated list
+func splitList(list string) []string {
+	var values []string
+
+	for _, value := range strings.Split(list, ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+
+	return values
+}
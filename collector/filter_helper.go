@@ -0,0 +1,72 @@
+// Copyright 2023 enthus GmbH
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"fmt"
+	"regexp"
+
+	"golang.org/x/exp/slices"
+)
+
+// entityFilter includes or excludes entities by name, role attributes and tags
+type entityFilter struct {
+	nameInclude  *regexp.Regexp
+	nameExclude  *regexp.Regexp
+	roleAttrAny  []string
+	roleAttrAll  []string
+	roleAttrNone []string
+	tags         map[string]string
+}
+
+// matches returns true if the entity is included by the filter. An entity with several names,
+// like a fabric link named by its routers, is included if any name is included and none is excluded.
+func (f *entityFilter) matches(names, roleAttr []string, tags map[string]interface{}) bool {
+	if f == nil {
+		return true
+	}
+
+	if f.nameInclude != nil && !slices.ContainsFunc(names, f.nameInclude.MatchString) {
+		return false
+	}
+
+	if f.nameExclude != nil && slices.ContainsFunc(names, f.nameExclude.MatchString) {
+		return false
+	}
+
+	if len(f.roleAttrAny) > 0 && !slices.ContainsFunc(f.roleAttrAny, func(attr string) bool { return slices.Contains(roleAttr, attr) }) {
+		return false
+	}
+
+	for _, attr := range f.roleAttrAll {
+		if !slices.Contains(roleAttr, attr) {
+			return false
+		}
+	}
+
+	for _, attr := range f.roleAttrNone {
+		if slices.Contains(roleAttr, attr) {
+			return false
+		}
+	}
+
+	for key, value := range f.tags {
+		tag, ok := tags[key]
+		if !ok || fmt.Sprint(tag) != value {
+			return false
+		}
+	}
+
+	return true
+}
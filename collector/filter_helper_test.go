@@ -0,0 +1,69 @@
+// Copyright 2023 enthus GmbH
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"testing"
+)
+
+func TestEntityFilterMatches(t *testing.T) {
+	newFilter := func(include, exclude, any, all, none, tags string) *entityFilter {
+		flags := &entityFilterFlags{&include, &exclude, &any, &all, &none, &tags}
+
+		filter, err := flags.entityFilter()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return filter
+	}
+
+	for _, tc := range []struct {
+		name     string
+		filter   *entityFilter
+		names    []string
+		roleAttr []string
+		tags     map[string]interface{}
+		want     bool
+	}{
+		{"no filter", nil, []string{"router-a"}, nil, nil, true},
+		{"empty filter", newFilter("", "", "", "", "", ""), []string{"router-a"}, nil, nil, true},
+		{"name included", newFilter("router-.*", "", "", "", "", ""), []string{"router-a"}, nil, nil, true},
+		{"name include is anchored", newFilter("router", "", "", "", "", ""), []string{"router-a"}, nil, nil, false},
+		{"name excluded", newFilter("", "router-a", "", "", "", ""), []string{"router-b", "router-a"}, nil, nil, false},
+		{"any role attribute", newFilter("", "", "eu,us", "", "", ""), []string{"a"}, []string{"us"}, nil, true},
+		{"missing any role attribute", newFilter("", "", "eu,us", "", "", ""), []string{"a"}, []string{"ap"}, nil, false},
+		{"all role attributes", newFilter("", "", "", "eu,prod", "", ""), []string{"a"}, []string{"eu"}, nil, false},
+		{"none role attributes", newFilter("", "", "", "", "test", ""), []string{"a"}, []string{"eu", "test"}, nil, false},
+		{"tags", newFilter("", "", "", "", "", "owner=team-a, site=1"), []string{"a"}, nil, map[string]interface{}{"owner": "team-a", "site": 1}, true},
+		{"tag mismatch", newFilter("", "", "", "", "", "owner=team-a"), []string{"a"}, nil, map[string]interface{}{"owner": "team-b"}, false},
+	} {
+		if have := tc.filter.matches(tc.names, tc.roleAttr, tc.tags); have != tc.want {
+			t.Errorf("%s: want %v, have %v", tc.name, tc.want, have)
+		}
+	}
+}
+
+func TestEntityFilterInvalid(t *testing.T) {
+	invalidRegexp, invalidTag, empty := "(", "owner", ""
+
+	for _, flags := range []*entityFilterFlags{
+		{&invalidRegexp, &empty, &empty, &empty, &empty, &empty},
+		{&empty, &empty, &empty, &empty, &empty, &invalidTag},
+	} {
+		if _, err := flags.entityFilter(); err == nil {
+			t.Errorf("want error for %+v", flags)
+		}
+	}
+}
@@ -17,9 +17,10 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
-	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	jsoniter "github.com/json-iterator/go"
@@ -39,10 +40,28 @@ import (
 type identitiesCollector struct {
 	logger  log.Logger
 	options *LoginOptions
+	filter  *entityFilter
+
+	// identity activity tracked across scrapes, by identity id
+	activityMtx  sync.Mutex
+	firstSeen    map[string]float64
+	lastActiveAt map[string]float64
+}
+
+// identityAggregate is the label set of the aggregated identities metrics
+type identityAggregate struct {
+	typeID                  string
+	sdkType                 string
+	sdkVersion              string
+	os                      string
+	hasAPISession           bool
+	hasEdgeRouterConnection bool
 }
 
 const (
-	identitySpace = "identity"
+	identitySpace         = "identity"
+	adminIdentitiesFilter = "isAdmin = true"
+	routerIdentityType    = "Router"
 )
 
 func init() {
@@ -51,20 +70,22 @@ func init() {
 
 // newIdentitiesCollector returns a new Collector exposing OpenZiti Identities metrics.
 func newIdentitiesCollector(logger log.Logger, options *LoginOptions) (Collector, error) {
+	filter, err := zitiIdentityEntityFilter.entityFilter()
+	if err != nil {
+		return nil, err
+	}
+
 	return &identitiesCollector{
-		logger:  logger,
-		options: options,
+		logger:       logger,
+		options:      options,
+		filter:       filter,
+		firstSeen:    make(map[string]float64),
+		lastActiveAt: make(map[string]float64),
 	}, nil
 }
 
 // edgeAPILogin returns a session token from edge/management/v1.
 func edgeAPILogin(logger log.Logger) (*LoginOptions, error) {
-	identityTypeFilter, err := getIdentityTypesFilter()
-	if err != nil {
-		level.Error(logger).Log("err", err)
-		os.Exit(1)
-	}
-
 	options := &LoginOptions{
 		Options: api.Options{
 			CommonOptions:      common.CommonOptions{BatchMode: true},
@@ -76,9 +97,9 @@ func edgeAPILogin(logger log.Logger) (*LoginOptions, error) {
 		ReadOnly:        true,
 		Yes:             true,
 		Logger:          logger,
-		IdentTypeFilter: identityTypeFilter,
+		IdentTypeFilter: getIdentityTypesFilter(),
 	}
-	err = options.RunLogin()
+	err := options.RunLogin()
 
 	return options, err
 }
@@ -108,54 +129,400 @@ func (c *identitiesCollector) Update(ch chan<- prometheus.Metric) (err error) {
 		zitiLoginSuccess++
 	}
 
-	identities, err := c.options.RunIdentities()
+	identities, err := c.options.RunIdentities(*zitiIdentityFilter)
 	if err != nil {
 		return err
 	}
 
+	minVersions, err := sdkMinVersions()
+	if err != nil {
+		return err
+	}
+
+	nonCompliant := make(map[string]float64)
+	for sdkType := range minVersions {
+		nonCompliant[sdkType] = 0
+	}
+
+	var (
+		now                = time.Now()
+		neverConnectedTime = float64(now.AddDate(0, 0, -*zitiIdentityNeverConnectedDays).Unix())
+		inactiveTime       = float64(now.AddDate(0, 0, -*zitiIdentityInactiveDays).Unix())
+		neverConnected     float64
+		inactive           float64
+		seen               = make(map[string]bool)
+		aggregates         = make(map[identityAggregate]float64)
+		roleAttrAggregates = make(map[string]float64)
+		allowList          = splitList(*zitiIdentityAllowList)
+	)
+
 	for i := range identities.Data {
+		if !c.filter.matches([]string{identities.Data[i].Name}, identities.Data[i].RoleAttributes, identities.Data[i].Tags) {
+			continue
+		}
+
+		perIdentity := !*zitiIdentityAggregateOnly ||
+			slices.Contains(allowList, identities.Data[i].Name) || slices.Contains(allowList, identities.Data[i].ID)
+
+		if perIdentity {
+			c.updateIdentity(ch, &identities.Data[i])
+		}
+
+		if minVersion, ok := minVersions[identities.Data[i].SdkInfo.Type]; ok {
+			compliant := versionCompliant(identities.Data[i].SdkInfo.Version, minVersion)
+			if !compliant {
+				nonCompliant[identities.Data[i].SdkInfo.Type]++
+			}
+
+			if perIdentity {
+				ch <- prometheus.MustNewConstMetric(
+					prometheus.NewDesc(
+						prometheus.BuildFQName(namespace, "",
+							"version_compliant"),
+						"Router or identity SDK version is greater or equal to the configured minimum version.",
+						[]string{"kind", "name"}, nil,
+					), prometheus.GaugeValue,
+					convertBool2Float(compliant),
+					"identity",
+					identities.Data[i].Name,
+				)
+			}
+		}
+
+		lastActivity, hasActivity := c.lastActivity(&identities.Data[i], float64(now.Unix()), seen)
+
+		staleReason := identityStaleReason(&identities.Data[i], lastActivity, neverConnectedTime, inactiveTime)
+
+		switch staleReason {
+		case "never_connected":
+			neverConnected++
+		case "inactive":
+			inactive++
+		}
+
+		if perIdentity && hasActivity {
+			ch <- prometheus.MustNewConstMetric(
+				prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, identitySpace,
+						"last_activity_timestamp_seconds"),
+					"Identity last seen with an API session or an edge router connection, tracked since the exporter start.",
+					identityLabelNames(), nil,
+				), prometheus.GaugeValue,
+				lastActivity,
+				identityLabelValues(&identities.Data[i])...,
+			)
+		}
+
+		if perIdentity && staleReason != "" {
+			ch <- prometheus.MustNewConstMetric(
+				prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, identitySpace,
+						"stale"),
+					"Identity is stale. (never_connected: created before the threshold and never connected, inactive: not seen connected since the threshold)",
+					append(identityLabelNames(), "reason"), nil,
+				), prometheus.GaugeValue,
+				1,
+				append(identityLabelValues(&identities.Data[i]), staleReason)...,
+			)
+		}
+
+		if !*zitiIdentityAggregateOnly {
+			continue
+		}
+
+		aggregates[identityAggregate{
+			typeID:                  identities.Data[i].TypeID,
+			sdkType:                 identities.Data[i].SdkInfo.Type,
+			sdkVersion:              identities.Data[i].SdkInfo.Version,
+			os:                      identities.Data[i].EnvInfo.Os,
+			hasAPISession:           identities.Data[i].HasAPISession,
+			hasEdgeRouterConnection: identities.Data[i].HasEdgeRouterConnection,
+		}]++
+
+		for _, roleAttr := range identities.Data[i].RoleAttributes {
+			roleAttrAggregates[roleAttr]++
+		}
+	}
+
+	c.pruneActivity(seen)
+
+	for sdkType, count := range nonCompliant {
+		ch <- prometheus.MustNewConstMetric(
+			prometheus.NewDesc(
+				prometheus.BuildFQName(namespace, "identities",
+					"version_non_compliant"),
+				"Number of identities running a SDK version lower than the configured minimum version.",
+				[]string{"sdk_type"}, nil,
+			), prometheus.GaugeValue,
+			count,
+			sdkType,
+		)
+	}
+
+	ch <- prometheus.MustNewConstMetric(
+		prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "identities",
+				"never_connected"),
+			"Number of identities created before the threshold which never connected.",
+			nil, nil,
+		), prometheus.GaugeValue,
+		neverConnected,
+	)
+	ch <- prometheus.MustNewConstMetric(
+		prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "identities",
+				"inactive"),
+			"Number of identities not seen connected since the threshold.",
+			nil, nil,
+		), prometheus.GaugeValue,
+		inactive,
+	)
+
+	for aggregate, count := range aggregates {
+		ch <- prometheus.MustNewConstMetric(
+			prometheus.NewDesc(
+				prometheus.BuildFQName(namespace, "",
+					"identities"),
+				"Number of identities by type, SDK, operating system and connection state.",
+				[]string{"type", "sdk_type", "sdk_version", "os", "has_api_session", "has_edge_router_connection"}, nil,
+			), prometheus.GaugeValue,
+			count,
+			aggregate.typeID,
+			aggregate.sdkType,
+			aggregate.sdkVersion,
+			aggregate.os,
+			strconv.FormatBool(aggregate.hasAPISession),
+			strconv.FormatBool(aggregate.hasEdgeRouterConnection),
+		)
+	}
+
+	for roleAttr, count := range roleAttrAggregates {
+		ch <- prometheus.MustNewConstMetric(
+			prometheus.NewDesc(
+				prometheus.BuildFQName(namespace, "identities",
+					"by_role_attribute"),
+				"Number of identities by role attribute.",
+				[]string{"attribute"}, nil,
+			), prometheus.GaugeValue,
+			count,
+			roleAttr,
+		)
+	}
+
+	admins, err := c.options.RunAdminIdentities()
+	if err != nil {
+		return err
+	}
+
+	defaultAdminEnabled := false
+
+	for i := range admins.Data {
+		if admins.Data[i].IsDefaultAdmin && !admins.Data[i].Disabled {
+			defaultAdminEnabled = true
+		}
+
 		ch <- prometheus.MustNewConstMetric(
 			prometheus.NewDesc(
 				prometheus.BuildFQName(namespace, identitySpace,
-					"has_api_session"),
-				"Identity has an API session active.",
-				[]string{"name", "type", "sdk_type", "sdk_version"}, nil,
+					"admin_info"),
+				"Identity with administrator privileges.",
+				[]string{"id", "name", "default_admin", "disabled"}, nil,
 			), prometheus.GaugeValue,
-			convertBool2Float(identities.Data[i].HasAPISession),
-			identities.Data[i].Name,
-			identities.Data[i].TypeID,
-			identities.Data[i].SdkInfo.Type,
-			identities.Data[i].SdkInfo.Version,
+			1,
+			admins.Data[i].ID,
+			admins.Data[i].Name,
+			strconv.FormatBool(admins.Data[i].IsDefaultAdmin),
+			strconv.FormatBool(admins.Data[i].Disabled),
 		)
+	}
+
+	ch <- prometheus.MustNewConstMetric(
+		prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, identitySpace,
+				"admins"),
+			"Number of identities with administrator privileges.",
+			nil, nil,
+		), prometheus.GaugeValue,
+		float64(len(admins.Data)),
+	)
+	ch <- prometheus.MustNewConstMetric(
+		prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, identitySpace,
+				"default_admin_enabled"),
+			"Default administrator identity exists and is enabled.",
+			nil, nil,
+		), prometheus.GaugeValue,
+		convertBool2Float(defaultAdminEnabled),
+	)
+
+	return nil
+}
+
+// updateIdentity pushes the metrics of a single identity onto ch
+func (c *identitiesCollector) updateIdentity(ch chan<- prometheus.Metric, identity *Identity) {
+	ch <- prometheus.MustNewConstMetric(
+		prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, identitySpace,
+				"info"),
+			"Identity SDK and environment information.",
+			append(append([]string{
+				"id", "name", "type",
+				"sdk_type", "sdk_version", "sdk_app_id", "sdk_app_version", "sdk_branch", "sdk_revision",
+				"os", "arch", "os_version", "os_release", "domain", "hostname",
+			}, tagLabelNames()...), appDataLabelNames()...), nil,
+		), prometheus.GaugeValue,
+		1,
+		append(append([]string{
+			identity.ID,
+			identity.Name,
+			identity.TypeID,
+			identity.SdkInfo.Type,
+			identity.SdkInfo.Version,
+			identity.SdkInfo.AppID,
+			identity.SdkInfo.AppVersion,
+			identity.SdkInfo.Branch,
+			identity.SdkInfo.Revision,
+			identity.EnvInfo.Os,
+			identity.EnvInfo.Arch,
+			identity.EnvInfo.OsVersion,
+			identity.EnvInfo.OsRelease,
+			identity.EnvInfo.Domain,
+			identity.EnvInfo.Hostname,
+		}, tagLabelValues(identity.Tags)...), appDataLabelValues(identity.AppData)...)...,
+	)
+
+	for _, roleAttr := range identity.RoleAttributes {
 		ch <- prometheus.MustNewConstMetric(
 			prometheus.NewDesc(
 				prometheus.BuildFQName(namespace, identitySpace,
-					"has_edge_router_connection"),
-				"Identity has an edge router connection active.",
-				[]string{"name", "type", "sdk_type", "sdk_version"}, nil,
+					"role_attribute"),
+				"Identity role attribute, one series per attribute.",
+				[]string{"id", "identity", "attribute"}, nil,
 			), prometheus.GaugeValue,
-			convertBool2Float(identities.Data[i].HasEdgeRouterConnection),
-			identities.Data[i].Name,
-			identities.Data[i].TypeID,
-			identities.Data[i].SdkInfo.Type,
-			identities.Data[i].SdkInfo.Version,
+			1,
+			identity.ID,
+			identity.Name,
+			roleAttr,
 		)
+	}
+
+	ch <- prometheus.MustNewConstMetric(
+		prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, identitySpace,
+				"has_api_session"),
+			"Identity has an API session active.",
+			identityLabelNames(), nil,
+		), prometheus.GaugeValue,
+		convertBool2Float(identity.HasAPISession),
+		identityLabelValues(identity)...,
+	)
+	ch <- prometheus.MustNewConstMetric(
+		prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, identitySpace,
+				"has_edge_router_connection"),
+			"Identity has an edge router connection active.",
+			identityLabelNames(), nil,
+		), prometheus.GaugeValue,
+		convertBool2Float(identity.HasEdgeRouterConnection),
+		identityLabelValues(identity)...,
+	)
+	ch <- prometheus.MustNewConstMetric(
+		prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, identitySpace,
+				"last_update_timestamp_seconds"),
+			"Identity last update timestamp.",
+			identityLabelNames(), nil,
+		), prometheus.GaugeValue,
+		convertRFC33339toUnix(identity.UpdatedAt),
+		identityLabelValues(identity)...,
+	)
+	ch <- prometheus.MustNewConstMetric(
+		prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, identitySpace,
+				"disabled"),
+			"Identity is currently disabled.",
+			identityLabelNames(), nil,
+		), prometheus.GaugeValue,
+		convertBool2Float(identity.Disabled),
+		identityLabelValues(identity)...,
+	)
+
+	if !identity.Disabled {
+		return
+	}
+
+	ch <- prometheus.MustNewConstMetric(
+		prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, identitySpace,
+				"disabled_reason"),
+			"Identity disable reason. (locked: disabled until a given time, disabled: disabled indefinitely)",
+			append(identityLabelNames(), "reason"), nil,
+		), prometheus.GaugeValue,
+		1,
+		append(identityLabelValues(identity), identityDisabledReason(identity))...,
+	)
+
+	if identity.DisabledAt != "" {
 		ch <- prometheus.MustNewConstMetric(
 			prometheus.NewDesc(
 				prometheus.BuildFQName(namespace, identitySpace,
-					"last_update_timestamp_seconds"),
-				"Identity last update timestamp.",
-				[]string{"name", "type", "sdk_type", "sdk_version"}, nil,
+					"disabled_timestamp_seconds"),
+				"Identity disabled timestamp.",
+				identityLabelNames(), nil,
 			), prometheus.GaugeValue,
-			convertRFC33339toUnix(identities.Data[i].UpdatedAt),
-			identities.Data[i].Name,
-			identities.Data[i].TypeID,
-			identities.Data[i].SdkInfo.Type,
-			identities.Data[i].SdkInfo.Version,
+			convertRFC33339toUnix(identity.DisabledAt),
+			identityLabelValues(identity)...,
 		)
 	}
 
-	return nil
+	if identity.DisabledUntil != "" {
+		ch <- prometheus.MustNewConstMetric(
+			prometheus.NewDesc(
+				prometheus.BuildFQName(namespace, identitySpace,
+					"disabled_until_timestamp_seconds"),
+				"Identity disabled until timestamp, when the lock lifts.",
+				identityLabelNames(), nil,
+			), prometheus.GaugeValue,
+			convertRFC33339toUnix(identity.DisabledUntil),
+			identityLabelValues(identity)...,
+		)
+	}
+}
+
+// lastActivity records whether an identity is connected and returns the last time it was seen connected.
+// An identity never seen connected returns the time it was first seen, and false.
+func (c *identitiesCollector) lastActivity(identity *Identity, now float64, seen map[string]bool) (float64, bool) {
+	c.activityMtx.Lock()
+	defer c.activityMtx.Unlock()
+
+	seen[identity.ID] = true
+
+	if _, ok := c.firstSeen[identity.ID]; !ok {
+		c.firstSeen[identity.ID] = now
+	}
+
+	if identity.HasAPISession || identity.HasEdgeRouterConnection {
+		c.lastActiveAt[identity.ID] = now
+	}
+
+	if lastActive, ok := c.lastActiveAt[identity.ID]; ok {
+		return lastActive, true
+	}
+
+	return c.firstSeen[identity.ID], false
+}
+
+// pruneActivity forgets the identities not returned by the controller anymore
+func (c *identitiesCollector) pruneActivity(seen map[string]bool) {
+	c.activityMtx.Lock()
+	defer c.activityMtx.Unlock()
+
+	for id := range c.firstSeen {
+		if !seen[id] {
+			delete(c.firstSeen, id)
+			delete(c.lastActiveAt, id)
+		}
+	}
 }
 
 // RunLogin implements this command
@@ -234,7 +601,7 @@ func (o *LoginOptions) RunLogin() error {
 }
 
 // RunIdentities implements this command
-func (o *LoginOptions) RunIdentities() (Identities, error) {
+func (o *LoginOptions) RunIdentities(filter string) (Identities, error) {
 	var (
 		limit                         = 50
 		offset                        = 0
@@ -242,7 +609,10 @@ func (o *LoginOptions) RunIdentities() (Identities, error) {
 		json                          = jsoniter.ConfigCompatibleWithStandardLibrary
 	)
 
-	jsonBytes, err := controllerAPICall(o, "edge_management", "/identities", limit, offset)
+	// unknown identity types are reported once as a configuration error, they match no identity
+	_ = o.validateIdentityTypes()
+
+	jsonBytes, err := controllerAPICall(o, "edge_management", "/identities", filter, limit, offset)
 	if err != nil {
 		return identStructTotal, err
 	}
@@ -265,7 +635,7 @@ func (o *LoginOptions) RunIdentities() (Identities, error) {
 	for offset+limit < totalIdentityCount {
 		offset += limit
 
-		jsonBytes, err := controllerAPICall(o, "edge_management", "/identities", limit, offset)
+		jsonBytes, err := controllerAPICall(o, "edge_management", "/identities", filter, limit, offset)
 		if err != nil {
 			return identStructTotal, err
 		}
@@ -287,18 +657,114 @@ func (o *LoginOptions) RunIdentities() (Identities, error) {
 	return identStructTotal, err
 }
 
+// RunIdentityTypes implements this command
+func (o *LoginOptions) RunIdentityTypes() (IdentityTypes, error) {
+	var (
+		limit                                 = 50
+		offset                                = 0
+		identTypeStructTotal, identTypeStruct IdentityTypes
+		json                                  = jsoniter.ConfigCompatibleWithStandardLibrary
+	)
+
+	jsonBytes, err := controllerAPICall(o, "edge_management", "/identity-types", "", limit, offset)
+	if err != nil {
+		return identTypeStructTotal, err
+	}
+
+	err = json.Unmarshal(jsonBytes, &identTypeStruct)
+	if err != nil {
+		return identTypeStructTotal, err
+	}
+
+	identTypeStructTotal.Data = append(identTypeStructTotal.Data, identTypeStruct.Data...)
+
+	totalIdentityTypeCount := identTypeStruct.Meta.Pagination.TotalCount
+	level.Debug(o.Logger).Log("msg", "Total Ziti Identity Types found", "count", totalIdentityTypeCount)
+
+	for offset+limit < totalIdentityTypeCount {
+		offset += limit
+
+		jsonBytes, err := controllerAPICall(o, "edge_management", "/identity-types", "", limit, offset)
+		if err != nil {
+			return identTypeStructTotal, err
+		}
+
+		err = json.Unmarshal(jsonBytes, &identTypeStruct)
+		if err != nil {
+			return identTypeStructTotal, err
+		}
+
+		identTypeStructTotal.Data = append(identTypeStructTotal.Data, identTypeStruct.Data...)
+	}
+
+	return identTypeStructTotal, err
+}
+
+// RunAdminIdentities returns every administrator Identity, regardless of the Identity filters.
+func (o *LoginOptions) RunAdminIdentities() (Identities, error) {
+	return o.RunAllIdentities(adminIdentitiesFilter)
+}
+
+// RunAllIdentities returns every Identity matching the controller filter, regardless of the Identity filters.
+func (o *LoginOptions) RunAllIdentities(filter string) (Identities, error) {
+	var (
+		limit                         = 50
+		offset                        = 0
+		identStructTotal, identStruct Identities
+		json                          = jsoniter.ConfigCompatibleWithStandardLibrary
+	)
+
+	jsonBytes, err := controllerAPICall(o, "edge_management", "/identities", filter, limit, offset)
+	if err != nil {
+		return identStructTotal, err
+	}
+
+	err = json.Unmarshal(jsonBytes, &identStruct)
+	if err != nil {
+		return identStructTotal, err
+	}
+
+	identStructTotal.Data = append(identStructTotal.Data, identStruct.Data...)
+
+	totalIdentityCount := identStruct.Meta.Pagination.TotalCount
+	level.Debug(o.Logger).Log("msg", "Total Ziti Identities found", "filter", filter, "count", totalIdentityCount)
+
+	for offset+limit < totalIdentityCount {
+		offset += limit
+
+		jsonBytes, err := controllerAPICall(o, "edge_management", "/identities", filter, limit, offset)
+		if err != nil {
+			return identStructTotal, err
+		}
+
+		err = json.Unmarshal(jsonBytes, &identStruct)
+		if err != nil {
+			return identStructTotal, err
+		}
+
+		identStructTotal.Data = append(identStructTotal.Data, identStruct.Data...)
+	}
+
+	return identStructTotal, err
+}
+
 // containsIdentRoleAttr compare Identity RoleAttributes slices with the one from command-line.
 func containsIdentRoleAttr(roleAttr []string) bool {
+	return containsRoleAttr(roleAttr, *zitiIdentityRoleAttributes)
+}
+
+// containsRoleAttr compare RoleAttributes slices with a comma-separated filter.
+func containsRoleAttr(roleAttr []string, filter string) bool {
 	// if no filter was passed via the filter, return true
-	if *zitiIdentityRoleAttributes == "" {
+	if filter == "" {
 		return true
 	} else if len(roleAttr) == 0 {
 		return false
 	}
 
-	zitiIdentityRoleAttributesFilter := strings.Split(*zitiIdentityRoleAttributes, ",")
+	roleAttributesFilter := strings.Split(filter, ",")
 	for i := range roleAttr {
-		if slices.Contains(zitiIdentityRoleAttributesFilter, roleAttr[i]) {
+		if slices.Contains(roleAttributesFilter, roleAttr[i]) {
 			return true
 		}
 	}
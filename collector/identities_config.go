@@ -14,16 +14,26 @@
 package collector
 
 import (
+	"errors"
 	"fmt"
 	"strings"
+	"sync"
 
 	"github.com/alecthomas/kingpin/v2"
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
 	"golang.org/x/exp/slices"
 )
 
+const (
+	defaultIdentityTypes = "default,router"
+)
+
 var (
-	validIdentityTypes = []string{"default", "router"}
-	zitiMgtAPI         = kingpin.Flag(
+	identityTypesMtx     sync.Mutex
+	identityTypesChecked bool
+	identityTypesErr     error
+	zitiMgtAPI           = kingpin.Flag(
 		"ziti.mgt.api", "Ziti Management API.",
 	).Envar("ZITI_MGMT_API").Default("https://localhost:1281").String()
 	zitiAdminPassword = kingpin.Flag(
@@ -34,23 +44,106 @@ var (
 	).Short('u').Envar("ZITI_ADMIN_USER").Default("admin").String()
 	zitiIdentityTypes = kingpin.Flag(
 		"ziti.identity.types", "Ziti Identity Types comma-separated filter.",
-	).Envar("ZITI_IDENTITY_TYPES").Default(strings.Join(validIdentityTypes, ",")).String()
+	).Envar("ZITI_IDENTITY_TYPES").Default(defaultIdentityTypes).String()
 	zitiIdentityRoleAttributes = kingpin.Flag(
 		"ziti.identity.role.attributes", "Ziti Identity Role Attributes comma-separated filter.",
 	).Envar("ZITI_IDENTITY_ROLE_ATTRIBUTES").Default("").String()
+	zitiIdentityFilter = kingpin.Flag(
+		"collector.identities.filter", "Ziti filter expression evaluated by the controller when listing identities, e.g. 'anyOf(roleAttributes) = \"prod\" and typeId = \"Default\"'.",
+	).Envar("ZITI_IDENTITY_FILTER").Default("").String()
+	zitiIdentityAggregateOnly = kingpin.Flag(
+		"collector.identities.aggregate-only", "Expose aggregated identities metrics only, instead of per-identity metrics.",
+	).Default("false").Bool()
+	zitiIdentityAllowList = kingpin.Flag(
+		"collector.identities.allow-list", "Ziti Identity names or ids comma-separated list still exposing per-identity metrics in aggregate-only mode.",
+	).Default("").String()
+	zitiIdentityNeverConnectedDays = kingpin.Flag(
+		"collector.identities.never-connected-days", "Number of days after its creation an identity which never connected is reported as stale.",
+	).Default("30").Int()
+	zitiIdentityInactiveDays = kingpin.Flag(
+		"collector.identities.inactive-days", "Number of days an identity is not seen connected after which it is reported as stale, tracked since the exporter start.",
+	).Default("90").Int()
+	zitiIdentityEntityFilter = registerEntityFilterFlags("identities", "identities")
 )
 
-// getIdentityTypesFilter validate Ziti Identity Types.
-func getIdentityTypesFilter() ([]string, error) {
-	var validFilterIdentType []string
+// getIdentityTypesFilter returns the lower-cased Ziti Identity Types filter.
+func getIdentityTypesFilter() []string {
+	var filterIdentType []string
 
 	for _, value := range strings.Split(*zitiIdentityTypes, ",") {
-		if slices.Contains(validIdentityTypes, value) {
-			validFilterIdentType = append(validFilterIdentType, strings.ToLower(value))
-		} else {
-			return validFilterIdentType, fmt.Errorf("%v identity type not valid. Valid values are %v", value, strings.Join(validIdentityTypes, ","))
+		if value = strings.TrimSpace(value); value != "" {
+			filterIdentType = append(filterIdentType, strings.ToLower(value))
+		}
+	}
+
+	return filterIdentType
+}
+
+// errInvalidIdentityTypes reports an Identity Type of the filter unknown by the controller.
+var errInvalidIdentityTypes = errors.New("invalid --ziti.identity.types")
+
+// identityTypesCollectors are the collectors listing identities through the Identity Types filter.
+var identityTypesCollectors = []string{"identities", "policy_advisor"}
+
+// IdentityTypesFilterUsed returns true if an enabled collector lists identities through the Identity Types filter.
+func IdentityTypesFilterUsed() bool {
+	for _, name := range identityTypesCollectors {
+		if enabled, ok := collectorState[name]; ok && *enabled {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ValidateIdentityTypes checks at startup the Identity Types filter against the Identity Types known by the controller.
+// Only a configuration error is returned: an unreachable controller defers the check to the first scrape.
+func ValidateIdentityTypes(logger log.Logger) error {
+	options, err := edgeAPILogin(logger)
+	if err != nil {
+		level.Warn(logger).Log("msg", "unable to validate the identity types at startup, validated on the first scrape", "err", err)
+		return nil
+	}
+
+	return options.validateIdentityTypes()
+}
+
+// validateIdentityTypes checks the Identity Types filter against the Identity Types known by the controller.
+// The check is done once and only returns a configuration error: when the Identity Types can't be read, the
+// configured Identity Types are used as they are.
+func (o *LoginOptions) validateIdentityTypes() error {
+	identityTypesMtx.Lock()
+	defer identityTypesMtx.Unlock()
+
+	if identityTypesChecked {
+		return identityTypesErr
+	}
+
+	identityTypesChecked = true
+
+	identityTypes, err := o.RunIdentityTypes()
+	if err != nil {
+		level.Warn(o.Logger).Log("msg", "unable to validate the identity types, using the configured identity types", "err", err)
+		return nil
+	}
+
+	var validIdentityTypes []string
+
+	for i := range identityTypes.Data {
+		validIdentityTypes = append(validIdentityTypes, strings.ToLower(identityTypes.Data[i].Name))
+		if !slices.Contains(validIdentityTypes, strings.ToLower(identityTypes.Data[i].ID)) {
+			validIdentityTypes = append(validIdentityTypes, strings.ToLower(identityTypes.Data[i].ID))
+		}
+	}
+
+	for _, value := range o.IdentTypeFilter {
+		if !slices.Contains(validIdentityTypes, value) {
+			identityTypesErr = fmt.Errorf("%w: %v identity type not valid. Valid values are %v", errInvalidIdentityTypes, value, strings.Join(validIdentityTypes, ","))
+			level.Error(o.Logger).Log("msg", "configuration error", "err", identityTypesErr)
+
+			break
 		}
 	}
 
-	return validFilterIdentType, nil
+	return identityTypesErr
 }
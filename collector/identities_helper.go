@@ -29,3 +29,50 @@ func convertRFC33339toUnix(value string) float64 {
 	t, _ := time.Parse(time.RFC3339, value)
 	return float64(t.Unix())
 }
+
+// identityNeverConnected returns true if an Identity never reported its SDK information
+func identityNeverConnected(identity *Identity) bool {
+	return identity.SdkInfo.Type == "" && identity.SdkInfo.Version == "" && !identity.HasAPISession
+}
+
+// identityStaleReason returns why an Identity is stale: never connected since its creation before neverConnectedTime,
+// or not seen connected since inactiveTime.
+func identityStaleReason(identity *Identity, lastActivity float64, neverConnectedTime, inactiveTime float64) string {
+	switch {
+	case identityNeverConnected(identity):
+		if convertRFC33339toUnix(identity.CreatedAt) < neverConnectedTime {
+			return "never_connected"
+		}
+	case lastActivity < inactiveTime:
+		return "inactive"
+	}
+
+	return ""
+}
+
+// identityDisabledReason returns why an Identity is disabled: locked until a given time or disabled indefinitely
+func identityDisabledReason(identity *Identity) string {
+	if identity.DisabledUntil != "" {
+		return "locked"
+	}
+
+	return "disabled"
+}
+
+// identityLabelNames returns the label names of the Identity value metrics
+func identityLabelNames() []string {
+	if *legacyLabels {
+		return []string{"name", "type", "sdk_type", "sdk_version"}
+	}
+
+	return []string{"id"}
+}
+
+// identityLabelValues returns the label values of the Identity value metrics
+func identityLabelValues(identity *Identity) []string {
+	if *legacyLabels {
+		return []string{identity.Name, identity.TypeID, identity.SdkInfo.Type, identity.SdkInfo.Version}
+	}
+
+	return []string{identity.ID}
+}
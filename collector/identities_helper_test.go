@@ -0,0 +1,114 @@
+// Copyright 2023 enthus GmbH
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"testing"
+	"time"
+)
+
+func TestIdentityNeverConnected(t *testing.T) {
+	connected := Identity{}
+	connected.SdkInfo.Type = "ziti-sdk-golang"
+	connected.SdkInfo.Version = "v0.23.40"
+
+	for _, tc := range []struct {
+		name     string
+		identity Identity
+		want     bool
+	}{
+		{"no SDK information", Identity{}, true},
+		{"SDK information", connected, false},
+		{"API session without SDK information", Identity{HasAPISession: true}, false},
+	} {
+		if have := identityNeverConnected(&tc.identity); have != tc.want {
+			t.Errorf("%s: want %v, have %v", tc.name, tc.want, have)
+		}
+	}
+}
+
+func TestIdentityStaleReason(t *testing.T) {
+	var (
+		now                = time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+		neverConnectedTime = float64(now.AddDate(0, 0, -30).Unix())
+		inactiveTime       = float64(now.AddDate(0, 0, -90).Unix())
+		oldCreation        = now.AddDate(0, -6, 0).Format(time.RFC3339)
+		recentCreation     = now.AddDate(0, 0, -1).Format(time.RFC3339)
+		recentActivity     = float64(now.AddDate(0, 0, -1).Unix())
+		oldActivity        = float64(now.AddDate(0, -6, 0).Unix())
+	)
+
+	connected := Identity{CreatedAt: oldCreation}
+	connected.SdkInfo.Type = "ziti-sdk-golang"
+
+	for _, tc := range []struct {
+		name         string
+		identity     Identity
+		lastActivity float64
+		want         string
+	}{
+		{"never connected, created before the threshold", Identity{CreatedAt: oldCreation}, oldActivity, "never_connected"},
+		{"never connected, created after the threshold", Identity{CreatedAt: recentCreation}, recentActivity, ""},
+		{"connected, recent activity", connected, recentActivity, ""},
+		{"connected, old activity", connected, oldActivity, "inactive"},
+	} {
+		have := identityStaleReason(&tc.identity, tc.lastActivity, neverConnectedTime, inactiveTime)
+		if have != tc.want {
+			t.Errorf("%s: want %q, have %q", tc.name, tc.want, have)
+		}
+	}
+}
+
+func TestIdentitiesLastActivity(t *testing.T) {
+	c := &identitiesCollector{
+		firstSeen:    make(map[string]float64),
+		lastActiveAt: make(map[string]float64),
+	}
+
+	for _, tc := range []struct {
+		name       string
+		now        float64
+		identities []Identity
+		id         string
+		want       float64
+		wantActive bool
+	}{
+		{"first seen disconnected", 100, []Identity{{ID: "a"}, {ID: "b"}}, "a", 100, false},
+		{"still disconnected", 200, []Identity{{ID: "a"}, {ID: "b"}}, "a", 100, false},
+		{"seen with an API session", 300, []Identity{{ID: "a", HasAPISession: true}, {ID: "b"}}, "a", 300, true},
+		{"disconnected again", 400, []Identity{{ID: "a"}, {ID: "b"}}, "a", 300, true},
+		{"seen with an edge router connection", 500, []Identity{{ID: "a"}, {ID: "b", HasEdgeRouterConnection: true}}, "b", 500, true},
+		{"forgotten once gone", 600, []Identity{{ID: "b"}}, "b", 500, true},
+		{"seen again", 700, []Identity{{ID: "a"}, {ID: "b"}}, "a", 700, false},
+	} {
+		var (
+			seen                = make(map[string]bool)
+			have                float64
+			haveActive, tracked bool
+		)
+
+		for i := range tc.identities {
+			lastActivity, active := c.lastActivity(&tc.identities[i], tc.now, seen)
+			if tc.identities[i].ID == tc.id {
+				have, haveActive, tracked = lastActivity, active, true
+			}
+		}
+
+		c.pruneActivity(seen)
+
+		if !tracked || have != tc.want || haveActive != tc.wantActive {
+			t.Errorf("%s: want %v %v, have %v %v", tc.name, tc.want, tc.wantActive, have, haveActive)
+		}
+	}
+}
@@ -21,18 +21,51 @@ type Identities struct {
 // Identity represent the meaningful chracteristics of a Ziti Identity
 // for this exporter
 type Identity struct {
-	CreatedAt               string   `json:"createdAt"`
-	UpdatedAt               string   `json:"updatedAt"`
-	Disabled                bool     `json:"disabled"`
+	AppData       map[string]interface{} `json:"appData"`
+	AuthPolicyID  string                 `json:"authPolicyId"`
+	CreatedAt     string                 `json:"createdAt"`
+	UpdatedAt     string                 `json:"updatedAt"`
+	Disabled      bool                   `json:"disabled"`
+	DisabledAt    string                 `json:"disabledAt"`
+	DisabledUntil string                 `json:"disabledUntil"`
+	EnvInfo       struct {
+		Arch      string `json:"arch"`
+		Domain    string `json:"domain"`
+		Hostname  string `json:"hostname"`
+		Os        string `json:"os"`
+		OsRelease string `json:"osRelease"`
+		OsVersion string `json:"osVersion"`
+	} `json:"envInfo"`
 	HasAPISession           bool     `json:"hasApiSession"`
 	HasEdgeRouterConnection bool     `json:"hasEdgeRouterConnection"`
+	ID                      string   `json:"id"`
+	IsAdmin                 bool     `json:"isAdmin"`
+	IsDefaultAdmin          bool     `json:"isDefaultAdmin"`
+	IsMfaEnabled            bool     `json:"isMfaEnabled"`
 	Name                    string   `json:"name"`
 	RoleAttributes          []string `json:"roleAttributes"`
 	SdkInfo                 struct {
-		Type    string `json:"type"`
-		Version string `json:"version"`
+		AppID      string `json:"appId"`
+		AppVersion string `json:"appVersion"`
+		Branch     string `json:"branch"`
+		Revision   string `json:"revision"`
+		Type       string `json:"type"`
+		Version    string `json:"version"`
 	} `json:"sdkInfo"`
-	TypeID string `json:"typeId"`
+	Tags   map[string]interface{} `json:"tags"`
+	TypeID string                 `json:"typeId"`
+}
+
+type IdentityTypes struct {
+	Data []IdentityType `json:"data"`
+	Meta MetaData       `json:"meta"`
+}
+
+// IdentityType represent the meaningful chracteristics of a Ziti Identity Type
+// for this exporter
+type IdentityType struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
 }
 
 // MetaData represent the pagination part of a Ziti Identity call
@@ -0,0 +1,30 @@
+// Copyright 2023 enthus GmbH
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"github.com/alecthomas/kingpin/v2"
+)
+
+var (
+	zitiTagLabels = kingpin.Flag(
+		"collector.tag-labels", "Comma-separated entity tag keys exposed as tag_<key> labels on the *_info metrics.",
+	).Default("").String()
+	zitiAppDataLabels = kingpin.Flag(
+		"collector.app-data-labels", "Comma-separated identity appData keys exposed as app_data_<key> labels on the openziti_identity_info metric.",
+	).Default("").String()
+	zitiLabelsLimit = kingpin.Flag(
+		"collector.tag-labels.limit", "Maximum number of tag and appData keys exposed as labels.",
+	).Default("10").Int()
+)
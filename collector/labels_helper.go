@@ -0,0 +1,97 @@
+// Copyright 2023 enthus GmbH
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"fmt"
+	"regexp"
+
+	"golang.org/x/exp/slices"
+)
+
+var invalidLabelCharRE = regexp.MustCompile(`[^a-zA-Z0-9_]`)
+
+// sanitizeLabelName returns a valid Prometheus label name for a tag key with the given prefix
+func sanitizeLabelName(prefix, key string) string {
+	return prefix + invalidLabelCharRE.ReplaceAllString(key, "_")
+}
+
+// validateTagLabels checks the tag and appData keys mapped to labels.
+func validateTagLabels() error {
+	tagKeys, appDataKeys := splitList(*zitiTagLabels), splitList(*zitiAppDataLabels)
+
+	if len(tagKeys)+len(appDataKeys) > *zitiLabelsLimit {
+		return fmt.Errorf("too many tag and appData keys mapped to labels: %d, limit is %d", len(tagKeys)+len(appDataKeys), *zitiLabelsLimit)
+	}
+
+	var labelNames []string
+
+	for _, labelName := range append(tagLabelNames(), appDataLabelNames()...) {
+		if slices.Contains(labelNames, labelName) {
+			return fmt.Errorf("tag and appData keys mapped to the same label %s", labelName)
+		}
+
+		labelNames = append(labelNames, labelName)
+	}
+
+	return nil
+}
+
+// tagLabelNames returns the label names of the tag keys mapped to labels
+func tagLabelNames() []string {
+	var labelNames []string
+
+	for _, key := range splitList(*zitiTagLabels) {
+		labelNames = append(labelNames, sanitizeLabelName("tag_", key))
+	}
+
+	return labelNames
+}
+
+// tagLabelValues returns the label values of the tag keys mapped to labels, empty for missing tags
+func tagLabelValues(tags map[string]interface{}) []string {
+	return mappedLabelValues(splitList(*zitiTagLabels), tags)
+}
+
+// appDataLabelNames returns the label names of the appData keys mapped to labels
+func appDataLabelNames() []string {
+	var labelNames []string
+
+	for _, key := range splitList(*zitiAppDataLabels) {
+		labelNames = append(labelNames, sanitizeLabelName("app_data_", key))
+	}
+
+	return labelNames
+}
+
+// appDataLabelValues returns the label values of the appData keys mapped to labels, empty for missing keys
+func appDataLabelValues(appData map[string]interface{}) []string {
+	return mappedLabelValues(splitList(*zitiAppDataLabels), appData)
+}
+
+func mappedLabelValues(keys []string, values map[string]interface{}) []string {
+	labelValues := make([]string, 0, len(keys))
+
+	for _, key := range keys {
+		value, ok := values[key]
+		if !ok || value == nil {
+			labelValues = append(labelValues, "")
+			continue
+		}
+
+		labelValues = append(labelValues, fmt.Sprint(value))
+	}
+
+	return labelValues
+}
@@ -0,0 +1,86 @@
+// Copyright 2023 enthus GmbH
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"strings"
+	"testing"
+)
+
+// setLabelFlags sets the tag and appData labels flags for the duration of a test
+func setLabelFlags(t *testing.T, tagLabels, appDataLabels string, limit int) {
+	t.Helper()
+
+	previousTags, previousAppData, previousLimit := *zitiTagLabels, *zitiAppDataLabels, *zitiLabelsLimit
+	*zitiTagLabels, *zitiAppDataLabels, *zitiLabelsLimit = tagLabels, appDataLabels, limit
+
+	t.Cleanup(func() {
+		*zitiTagLabels, *zitiAppDataLabels, *zitiLabelsLimit = previousTags, previousAppData, previousLimit
+	})
+}
+
+func TestSanitizeLabelName(t *testing.T) {
+	for _, tc := range []struct {
+		key, want string
+	}{
+		{"owner", "tag_owner"},
+		{"cost-center", "tag_cost_center"},
+		{"site.name", "tag_site_name"},
+		{"Team 1", "tag_Team_1"},
+	} {
+		if have := sanitizeLabelName("tag_", tc.key); have != tc.want {
+			t.Errorf("sanitizeLabelName(%q): want %q, have %q", tc.key, tc.want, have)
+		}
+	}
+}
+
+func TestTagLabels(t *testing.T) {
+	setLabelFlags(t, "owner, cost-center,site", "location", 10)
+
+	if have, want := strings.Join(tagLabelNames(), ","), "tag_owner,tag_cost_center,tag_site"; have != want {
+		t.Errorf("tagLabelNames: want %q, have %q", want, have)
+	}
+
+	if have, want := strings.Join(appDataLabelNames(), ","), "app_data_location"; have != want {
+		t.Errorf("appDataLabelNames: want %q, have %q", want, have)
+	}
+
+	values := tagLabelValues(map[string]interface{}{"owner": "team-a", "site": 1, "other": "x"})
+	if have, want := strings.Join(values, ","), "team-a,,1"; have != want {
+		t.Errorf("tagLabelValues: want %q, have %q", want, have)
+	}
+
+	if have := appDataLabelValues(nil); len(have) != 1 || have[0] != "" {
+		t.Errorf("appDataLabelValues without appData: want one empty value, have %q", have)
+	}
+}
+
+func TestValidateTagLabels(t *testing.T) {
+	for _, tc := range []struct {
+		name, tagLabels, appDataLabels string
+		limit                          int
+		wantErr                        bool
+	}{
+		{"no labels", "", "", 10, false},
+		{"within the limit", "owner,site", "location", 3, false},
+		{"above the limit", "owner,site", "location", 2, true},
+		{"same sanitized label", "cost-center,cost.center", "", 10, true},
+	} {
+		setLabelFlags(t, tc.tagLabels, tc.appDataLabels, tc.limit)
+
+		if err := validateTagLabels(); (err != nil) != tc.wantErr {
+			t.Errorf("%s: want error %v, have %v", tc.name, tc.wantErr, err)
+		}
+	}
+}
@@ -0,0 +1,243 @@
+// Copyright 2023 enthus GmbH
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"strings"
+
+	"github.com/go-kit/log/level"
+	jsoniter "github.com/json-iterator/go"
+	"golang.org/x/exp/slices"
+)
+
+// RunServicePolicies implements this command
+func (o *LoginOptions) RunServicePolicies() (ServicePolicies, error) {
+	var (
+		limit                                         = 50
+		offset                                        = 0
+		servicePolicyStructTotal, servicePolicyStruct ServicePolicies
+		json                                          = jsoniter.ConfigCompatibleWithStandardLibrary
+	)
+
+	jsonBytes, err := controllerAPICall(o, "edge_management", "/service-policies", "", limit, offset)
+	if err != nil {
+		return servicePolicyStructTotal, err
+	}
+
+	err = json.Unmarshal(jsonBytes, &servicePolicyStruct)
+	if err != nil {
+		return servicePolicyStructTotal, err
+	}
+
+	servicePolicyStructTotal.Data = append(servicePolicyStructTotal.Data, servicePolicyStruct.Data...)
+
+	totalServicePolicyCount := servicePolicyStruct.Meta.Pagination.TotalCount
+	level.Debug(o.Logger).Log("msg", "Total Ziti Service Policies found", "count", totalServicePolicyCount)
+
+	for offset+limit < totalServicePolicyCount {
+		offset += limit
+
+		jsonBytes, err := controllerAPICall(o, "edge_management", "/service-policies", "", limit, offset)
+		if err != nil {
+			return servicePolicyStructTotal, err
+		}
+
+		err = json.Unmarshal(jsonBytes, &servicePolicyStruct)
+		if err != nil {
+			return servicePolicyStructTotal, err
+		}
+
+		servicePolicyStructTotal.Data = append(servicePolicyStructTotal.Data, servicePolicyStruct.Data...)
+	}
+
+	return servicePolicyStructTotal, err
+}
+
+// RunEdgeRouterPolicies implements this command
+func (o *LoginOptions) RunEdgeRouterPolicies() (EdgeRouterPolicies, error) {
+	var (
+		limit                                               = 50
+		offset                                              = 0
+		edgeRouterPolicyStructTotal, edgeRouterPolicyStruct EdgeRouterPolicies
+		json                                                = jsoniter.ConfigCompatibleWithStandardLibrary
+	)
+
+	jsonBytes, err := controllerAPICall(o, "edge_management", "/edge-router-policies", "", limit, offset)
+	if err != nil {
+		return edgeRouterPolicyStructTotal, err
+	}
+
+	err = json.Unmarshal(jsonBytes, &edgeRouterPolicyStruct)
+	if err != nil {
+		return edgeRouterPolicyStructTotal, err
+	}
+
+	edgeRouterPolicyStructTotal.Data = append(edgeRouterPolicyStructTotal.Data, edgeRouterPolicyStruct.Data...)
+
+	totalEdgeRouterPolicyCount := edgeRouterPolicyStruct.Meta.Pagination.TotalCount
+	level.Debug(o.Logger).Log("msg", "Total Ziti Edge Router Policies found", "count", totalEdgeRouterPolicyCount)
+
+	for offset+limit < totalEdgeRouterPolicyCount {
+		offset += limit
+
+		jsonBytes, err := controllerAPICall(o, "edge_management", "/edge-router-policies", "", limit, offset)
+		if err != nil {
+			return edgeRouterPolicyStructTotal, err
+		}
+
+		err = json.Unmarshal(jsonBytes, &edgeRouterPolicyStruct)
+		if err != nil {
+			return edgeRouterPolicyStructTotal, err
+		}
+
+		edgeRouterPolicyStructTotal.Data = append(edgeRouterPolicyStructTotal.Data, edgeRouterPolicyStruct.Data...)
+	}
+
+	return edgeRouterPolicyStructTotal, err
+}
+
+// RunServiceEdgeRouterPolicies implements this command
+func (o *LoginOptions) RunServiceEdgeRouterPolicies() (ServiceEdgeRouterPolicies, error) {
+	var (
+		limit                                                             = 50
+		offset                                                            = 0
+		serviceEdgeRouterPolicyStructTotal, serviceEdgeRouterPolicyStruct ServiceEdgeRouterPolicies
+		json                                                              = jsoniter.ConfigCompatibleWithStandardLibrary
+	)
+
+	jsonBytes, err := controllerAPICall(o, "edge_management", "/service-edge-router-policies", "", limit, offset)
+	if err != nil {
+		return serviceEdgeRouterPolicyStructTotal, err
+	}
+
+	err = json.Unmarshal(jsonBytes, &serviceEdgeRouterPolicyStruct)
+	if err != nil {
+		return serviceEdgeRouterPolicyStructTotal, err
+	}
+
+	serviceEdgeRouterPolicyStructTotal.Data = append(serviceEdgeRouterPolicyStructTotal.Data, serviceEdgeRouterPolicyStruct.Data...)
+
+	totalServiceEdgeRouterPolicyCount := serviceEdgeRouterPolicyStruct.Meta.Pagination.TotalCount
+	level.Debug(o.Logger).Log("msg", "Total Ziti Service Edge Router Policies found", "count", totalServiceEdgeRouterPolicyCount)
+
+	for offset+limit < totalServiceEdgeRouterPolicyCount {
+		offset += limit
+
+		jsonBytes, err := controllerAPICall(o, "edge_management", "/service-edge-router-policies", "", limit, offset)
+		if err != nil {
+			return serviceEdgeRouterPolicyStructTotal, err
+		}
+
+		err = json.Unmarshal(jsonBytes, &serviceEdgeRouterPolicyStruct)
+		if err != nil {
+			return serviceEdgeRouterPolicyStructTotal, err
+		}
+
+		serviceEdgeRouterPolicyStructTotal.Data = append(serviceEdgeRouterPolicyStructTotal.Data, serviceEdgeRouterPolicyStruct.Data...)
+	}
+
+	return serviceEdgeRouterPolicyStructTotal, err
+}
+
+// RunRoleAttributes implements this command for the given entity type (identity, service or edge-router)
+func (o *LoginOptions) RunRoleAttributes(entityType string) (RoleAttributes, error) {
+	var (
+		limit                               = 50
+		offset                              = 0
+		roleAttrStructTotal, roleAttrStruct RoleAttributes
+		json                                = jsoniter.ConfigCompatibleWithStandardLibrary
+		endpoint                            = "/" + entityType + "-role-attributes"
+	)
+
+	jsonBytes, err := controllerAPICall(o, "edge_management", endpoint, "", limit, offset)
+	if err != nil {
+		return roleAttrStructTotal, err
+	}
+
+	err = json.Unmarshal(jsonBytes, &roleAttrStruct)
+	if err != nil {
+		return roleAttrStructTotal, err
+	}
+
+	roleAttrStructTotal.Data = append(roleAttrStructTotal.Data, roleAttrStruct.Data...)
+
+	totalRoleAttrCount := roleAttrStruct.Meta.Pagination.TotalCount
+	level.Debug(o.Logger).Log("msg", "Total Ziti Role Attributes found", "type", entityType, "count", totalRoleAttrCount)
+
+	for offset+limit < totalRoleAttrCount {
+		offset += limit
+
+		jsonBytes, err := controllerAPICall(o, "edge_management", endpoint, "", limit, offset)
+		if err != nil {
+			return roleAttrStructTotal, err
+		}
+
+		err = json.Unmarshal(jsonBytes, &roleAttrStruct)
+		if err != nil {
+			return roleAttrStructTotal, err
+		}
+
+		roleAttrStructTotal.Data = append(roleAttrStructTotal.Data, roleAttrStruct.Data...)
+	}
+
+	return roleAttrStructTotal, err
+}
+
+// policyRolesMatch returns true if the policy roles select the entity with the given id and role attributes.
+// Entities referenced by "@id" are always selected, "#all" selects every entity and the "#attribute"
+// roles are evaluated with the AllOf or AnyOf policy semantic.
+func policyRolesMatch(roles []string, semantic, id string, roleAttr []string) bool {
+	var attributes []string
+
+	for _, role := range roles {
+		switch {
+		case role == "#all":
+			return true
+		case strings.HasPrefix(role, "@"):
+			if role[1:] == id {
+				return true
+			}
+		case strings.HasPrefix(role, "#"):
+			attributes = append(attributes, role[1:])
+		}
+	}
+
+	if len(attributes) == 0 {
+		return false
+	}
+
+	for _, attribute := range attributes {
+		found := slices.Contains(roleAttr, attribute)
+		if semantic == policySemanticAllOf && !found {
+			return false
+		} else if semantic != policySemanticAllOf && found {
+			return true
+		}
+	}
+
+	return semantic == policySemanticAllOf
+}
+
+// policyRoleAttributes returns the "#attribute" roles referenced by the policy roles, "#all" excluded
+func policyRoleAttributes(roles []string) []string {
+	var attributes []string
+
+	for _, role := range roles {
+		if strings.HasPrefix(role, "#") && role != "#all" {
+			attributes = append(attributes, role[1:])
+		}
+	}
+
+	return attributes
+}
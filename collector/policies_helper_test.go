@@ -0,0 +1,39 @@
+// Copyright 2023 enthus GmbH
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"testing"
+)
+
+func TestPolicyRolesMatch(t *testing.T) {
+	for _, tc := range []struct {
+		roles    []string
+		semantic string
+		roleAttr []string
+		want     bool
+	}{
+		{[]string{"#all"}, "AnyOf", nil, true},
+		{[]string{"@id1"}, "AllOf", nil, true},
+		{[]string{"@id2"}, "AnyOf", []string{"prod"}, false},
+		{[]string{"#prod", "#eu"}, "AnyOf", []string{"eu"}, true},
+		{[]string{"#prod", "#eu"}, "AllOf", []string{"eu"}, false},
+		{[]string{"#prod", "#eu"}, "AllOf", []string{"eu", "prod", "web"}, true},
+		{[]string{}, "AllOf", []string{"eu"}, false},
+	} {
+		if have := policyRolesMatch(tc.roles, tc.semantic, "id1", tc.roleAttr); have != tc.want {
+			t.Errorf("policyRolesMatch(%v, %s, %v): want %v, have %v", tc.roles, tc.semantic, tc.roleAttr, tc.want, have)
+		}
+	}
+}
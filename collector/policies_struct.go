@@ -0,0 +1,69 @@
+// Copyright 2023 enthus GmbH
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+const (
+	policySemanticAllOf = "AllOf"
+	servicePolicyDial   = "Dial"
+	servicePolicyBind   = "Bind"
+)
+
+type ServicePolicies struct {
+	Data []ServicePolicy `json:"data"`
+	Meta MetaData        `json:"meta"`
+}
+
+// ServicePolicy represent the meaningful chracteristics of a Ziti Service Policy
+// for this exporter
+type ServicePolicy struct {
+	IdentityRoles []string `json:"identityRoles"`
+	Name          string   `json:"name"`
+	Semantic      string   `json:"semantic"`
+	ServiceRoles  []string `json:"serviceRoles"`
+	Type          string   `json:"type"`
+}
+
+type EdgeRouterPolicies struct {
+	Data []EdgeRouterPolicy `json:"data"`
+	Meta MetaData           `json:"meta"`
+}
+
+// EdgeRouterPolicy represent the meaningful chracteristics of a Ziti Edge Router Policy
+// for this exporter
+type EdgeRouterPolicy struct {
+	EdgeRouterRoles []string `json:"edgeRouterRoles"`
+	IdentityRoles   []string `json:"identityRoles"`
+	Name            string   `json:"name"`
+	Semantic        string   `json:"semantic"`
+}
+
+type ServiceEdgeRouterPolicies struct {
+	Data []ServiceEdgeRouterPolicy `json:"data"`
+	Meta MetaData                  `json:"meta"`
+}
+
+// ServiceEdgeRouterPolicy represent the meaningful chracteristics of a Ziti Service Edge Router Policy
+// for this exporter
+type ServiceEdgeRouterPolicy struct {
+	EdgeRouterRoles []string `json:"edgeRouterRoles"`
+	Name            string   `json:"name"`
+	Semantic        string   `json:"semantic"`
+	ServiceRoles    []string `json:"serviceRoles"`
+}
+
+// RoleAttributes represent the role attributes in use by an entity type
+type RoleAttributes struct {
+	Data []string `json:"data"`
+	Meta MetaData `json:"meta"`
+}
@@ -0,0 +1,185 @@
+// Copyright 2023 enthus GmbH
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type policyAdvisorCollector struct {
+	logger  log.Logger
+	options *LoginOptions
+	filter  *entityFilter
+}
+
+const (
+	policyAdvisorSpace = "policy_advisor"
+)
+
+func init() {
+	registerCollector("policy_advisor", defaultDisabled, newPolicyAdvisorCollector)
+}
+
+// newPolicyAdvisorCollector returns a new Collector exposing OpenZiti Policy Advisor metrics.
+func newPolicyAdvisorCollector(logger log.Logger, options *LoginOptions) (Collector, error) {
+	filter, err := zitiPolicyAdvisorServiceEntityFilter.entityFilter()
+	if err != nil {
+		return nil, err
+	}
+
+	return &policyAdvisorCollector{
+		logger:  logger,
+		options: options,
+		filter:  filter,
+	}, nil
+}
+
+// Update pushes policy advisor metrics onto ch
+func (c *policyAdvisorCollector) Update(ch chan<- prometheus.Metric) (err error) {
+	// without an identity and a service selector every identity/service pair would be evaluated, one request each.
+	if *zitiPolicyAdvisorIdentityRoleAttributes == "" && *zitiPolicyAdvisorIdentityFilter == "" ||
+		*zitiPolicyAdvisorServiceRoleAttributes == "" && *zitiPolicyAdvisorServiceFilter == "" {
+		return ErrNoData
+	}
+
+	// if not already logged, do the login.
+	if c.options == nil {
+		c.options, err = edgeAPILogin(c.logger)
+		if err != nil {
+			errString := fmt.Sprintf("%s", errors.Unwrap(err))
+			zitiLoginErrors[errString]++
+
+			return err
+		}
+
+		zitiLoginSuccess++
+	} else if c.options.Token == "" {
+		c.options, err = edgeAPILogin(c.logger)
+		if err != nil {
+			errString := fmt.Sprintf("%s", errors.Unwrap(err))
+			zitiLoginErrors[errString]++
+
+			return err
+		}
+
+		zitiLoginSuccess++
+	}
+
+	identities, err := c.options.RunIdentities(*zitiPolicyAdvisorIdentityFilter)
+	if err != nil {
+		return err
+	}
+
+	services, err := c.options.RunServices(*zitiPolicyAdvisorServiceFilter)
+	if err != nil {
+		return err
+	}
+
+	for i := range identities.Data {
+		if !containsRoleAttr(identities.Data[i].RoleAttributes, *zitiPolicyAdvisorIdentityRoleAttributes) {
+			continue
+		}
+
+		for j := range services.Data {
+			if !containsRoleAttr(services.Data[j].RoleAttributes, *zitiPolicyAdvisorServiceRoleAttributes) ||
+				!c.filter.matches([]string{services.Data[j].Name}, services.Data[j].RoleAttributes, services.Data[j].Tags) {
+				continue
+			}
+
+			// a failing pair is skipped, the other pairs are still evaluated
+			advice, err := c.options.RunPolicyAdvice(identities.Data[i].ID, services.Data[j].ID)
+			if err != nil {
+				level.Warn(c.logger).Log("msg", "unable to get the policy advice", "identity", identities.Data[i].Name,
+					"service", services.Data[j].Name, "err", err)
+
+				continue
+			}
+
+			for _, check := range advice.checks() {
+				ch <- prometheus.MustNewConstMetric(
+					prometheus.NewDesc(
+						prometheus.BuildFQName(namespace, policyAdvisorSpace,
+							"ok"),
+						"Policy Advisor check result for an identity and a service.",
+						[]string{"identity", "service", "check"}, nil,
+					), prometheus.GaugeValue,
+					convertBool2Float(check.ok),
+					identities.Data[i].Name,
+					services.Data[j].Name,
+					check.name,
+				)
+			}
+		}
+	}
+
+	return nil
+}
+
+// RunPolicyAdvice implements this command
+func (o *LoginOptions) RunPolicyAdvice(identityID, serviceID string) (PolicyAdvice, error) {
+	var (
+		adviceStruct PolicyAdviceResponse
+		json         = jsoniter.ConfigCompatibleWithStandardLibrary
+	)
+
+	endpoint := "/identities/" + url.PathEscape(identityID) + "/policy-advisor/services/" + url.PathEscape(serviceID)
+
+	jsonBytes, err := controllerAPIGet(o, "edge_management", endpoint, nil)
+	if err != nil {
+		return adviceStruct.Data, err
+	}
+
+	err = json.Unmarshal(jsonBytes, &adviceStruct)
+	if err != nil {
+		return adviceStruct.Data, err
+	}
+
+	level.Debug(o.Logger).Log("msg", "Ziti Policy Advice", "identity", identityID, "service", serviceID,
+		"dial", adviceStruct.Data.IsDialAllowed, "bind", adviceStruct.Data.IsBindAllowed)
+
+	return adviceStruct.Data, err
+}
+
+type policyAdviceCheck struct {
+	name string
+	ok   bool
+}
+
+// checks returns the policy advisor checks in the order "ziti edge policy-advisor" evaluates them
+func (a *PolicyAdvice) checks() []policyAdviceCheck {
+	onlineCommonRouters := false
+
+	for i := range a.CommonRouters {
+		if a.CommonRouters[i].IsOnline {
+			onlineCommonRouters = true
+			break
+		}
+	}
+
+	return []policyAdviceCheck{
+		{name: "dial", ok: a.IsDialAllowed},
+		{name: "bind", ok: a.IsBindAllowed},
+		{name: "identity_edge_routers", ok: a.IdentityRouterCount > 0},
+		{name: "service_edge_routers", ok: a.ServiceRouterCount > 0},
+		{name: "common_routers", ok: len(a.CommonRouters) > 0},
+		{name: "online_common_routers", ok: onlineCommonRouters},
+	}
+}
@@ -0,0 +1,32 @@
+// Copyright 2023 enthus GmbH
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+type PolicyAdviceResponse struct {
+	Data PolicyAdvice `json:"data"`
+}
+
+// PolicyAdvice represent the meaningful chracteristics of a Ziti Policy Advisor result
+// for an Identity and a Service
+type PolicyAdvice struct {
+	CommonRouters []struct {
+		ID       string `json:"id"`
+		IsOnline bool   `json:"isOnline"`
+		Name     string `json:"name"`
+	} `json:"commonRouters"`
+	IdentityRouterCount int  `json:"identityRouterCount"`
+	IsBindAllowed       bool `json:"isBindAllowed"`
+	IsDialAllowed       bool `json:"isDialAllowed"`
+	ServiceRouterCount  int  `json:"serviceRouterCount"`
+}
@@ -18,16 +18,25 @@ import (
 	"fmt"
 	"math"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/go-kit/log"
 	"github.com/go-kit/log/level"
 	jsoniter "github.com/json-iterator/go"
 	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/exp/slices"
 )
 
 type routersCollector struct {
 	logger  log.Logger
 	options *LoginOptions
+	filter  *entityFilter
+
+	// synchronization status changes tracked across scrapes, by router id
+	syncMtx         sync.Mutex
+	syncStatuses    map[string]string
+	syncStatusMoves map[string]float64
 }
 
 const (
@@ -40,9 +49,17 @@ func init() {
 
 // newRoutersCollector returns a new Collector exposing OpenZiti Routers metrics.
 func newRoutersCollector(logger log.Logger, options *LoginOptions) (Collector, error) {
+	filter, err := zitiRouterEntityFilter.entityFilter()
+	if err != nil {
+		return nil, err
+	}
+
 	return &routersCollector{
-		logger:  logger,
-		options: options,
+		logger:          logger,
+		options:         options,
+		filter:          filter,
+		syncStatuses:    make(map[string]string),
+		syncStatusMoves: make(map[string]float64),
 	}, nil
 }
 
@@ -71,80 +88,296 @@ func (c *routersCollector) Update(ch chan<- prometheus.Metric) (err error) {
 		zitiLoginSuccess++
 	}
 
-	routers, err := c.options.RunRouters()
+	routers, err := c.options.RunRouters(*zitiRouterFilter)
 	if err != nil {
 		return err
 	}
 
+	var controllerRelease semVersion
+
+	// without the controller version, only the version skew is not exposed
+	controllerVersion, controllerErr := c.options.RunControllerVersion()
+	if controllerErr != nil {
+		level.Warn(c.logger).Log("msg", "unable to get the controller version, router version skew not exposed", "err", controllerErr)
+	} else {
+		ch <- prometheus.MustNewConstMetric(
+			prometheus.NewDesc(
+				prometheus.BuildFQName(namespace, "controller",
+					"build_info"),
+				"Controller version and build information.",
+				[]string{"version", "revision", "build_date", "runtime_version"}, nil,
+			), prometheus.GaugeValue,
+			1,
+			controllerVersion.Data.Version,
+			controllerVersion.Data.Revision,
+			controllerVersion.Data.BuildDate,
+			controllerVersion.Data.RuntimeVersion,
+		)
+
+		controllerRelease, controllerErr = parseVersion(controllerVersion.Data.Version)
+		if controllerErr != nil {
+			level.Debug(c.logger).Log("msg", "unable to compute the router version skew", "err", controllerErr)
+		}
+	}
+
+	minVersion, versionPolicy, err := routerMinVersion()
+	if err != nil {
+		return err
+	}
+
+	var (
+		seen             = make(map[string]bool)
+		nonCompliant     float64
+		now              = time.Now()
+		enrollmentStates = map[string]float64{
+			routerEnrollmentAwaitingEnrollment:   0,
+			routerEnrollmentExpired:              0,
+			routerEnrollmentAwaitingVerification: 0,
+		}
+	)
+
 	for i := range routers.Data {
+		if !c.filter.matches([]string{routers.Data[i].Name}, routers.Data[i].RoleAttributes, routers.Data[i].Tags) {
+			continue
+		}
+
+		enrollmentStates[routerEnrollmentState(&routers.Data[i], now)]++
+
+		ch <- prometheus.MustNewConstMetric(
+			prometheus.NewDesc(
+				prometheus.BuildFQName(namespace, routerSpace,
+					"info"),
+				"Router descriptive information.",
+				append([]string{"id", "name", "hostname", "role_attributes", "version"}, tagLabelNames()...), nil,
+			), prometheus.GaugeValue,
+			1,
+			append([]string{
+				routers.Data[i].ID,
+				routers.Data[i].Name,
+				routers.Data[i].Hostname,
+				strings.Join(routers.Data[i].RoleAttributes, " "),
+				routers.Data[i].VersionInfo.Version,
+			}, tagLabelValues(routers.Data[i].Tags)...)...,
+		)
+		for _, roleAttr := range routers.Data[i].RoleAttributes {
+			ch <- prometheus.MustNewConstMetric(
+				prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, routerSpace,
+						"role_attribute"),
+					"Router role attribute, one series per attribute.",
+					[]string{"id", "router", "attribute"}, nil,
+				), prometheus.GaugeValue,
+				1,
+				routers.Data[i].ID,
+				routers.Data[i].Name,
+				roleAttr,
+			)
+		}
+
 		ch <- prometheus.MustNewConstMetric(
 			prometheus.NewDesc(
 				prometheus.BuildFQName(namespace, routerSpace,
 					"online"),
 				"Router is currently online.",
-				[]string{"hostname", "role_attributes", "version"}, nil,
+				routerLabelNames(), nil,
 			), prometheus.GaugeValue,
 			convertBool2Float(routers.Data[i].IsOnline),
-			routers.Data[i].Hostname,
-			strings.Join(routers.Data[i].RoleAttributes, " "),
-			routers.Data[i].VersionInfo.Version,
+			routerLabelValues(&routers.Data[i])...,
 		)
 		ch <- prometheus.MustNewConstMetric(
 			prometheus.NewDesc(
 				prometheus.BuildFQName(namespace, routerSpace,
 					"enabled"),
 				"Router is currently enabled.",
-				[]string{"hostname", "role_attributes", "version"}, nil,
+				routerLabelNames(), nil,
 			), prometheus.GaugeValue,
 			convertBool2Float(!routers.Data[i].Disabled),
-			routers.Data[i].Hostname,
-			strings.Join(routers.Data[i].RoleAttributes, " "),
-			routers.Data[i].VersionInfo.Version,
+			routerLabelValues(&routers.Data[i])...,
 		)
 		ch <- prometheus.MustNewConstMetric(
 			prometheus.NewDesc(
 				prometheus.BuildFQName(namespace, routerSpace,
 					"tunneler_enabled"),
 				"Router as tunneler enabled.",
-				[]string{"hostname", "role_attributes", "version"}, nil,
+				routerLabelNames(), nil,
 			), prometheus.GaugeValue,
 			convertBool2Float(routers.Data[i].IsTunnelerEnabled),
-			routers.Data[i].Hostname,
-			strings.Join(routers.Data[i].RoleAttributes, " "),
-			routers.Data[i].VersionInfo.Version,
+			routerLabelValues(&routers.Data[i])...,
 		)
 		ch <- prometheus.MustNewConstMetric(
 			prometheus.NewDesc(
 				prometheus.BuildFQName(namespace, routerSpace,
 					"service_traversal_enabled"),
 				"Router let services traverse through.",
-				[]string{"hostname", "role_attributes", "version"}, nil,
+				routerLabelNames(), nil,
 			), prometheus.GaugeValue,
 			convertBool2Float(!routers.Data[i].NoTraversal),
-			routers.Data[i].Hostname,
-			strings.Join(routers.Data[i].RoleAttributes, " "),
-			routers.Data[i].VersionInfo.Version,
+			routerLabelValues(&routers.Data[i])...,
+		)
+		if *legacyLabels {
+			ch <- prometheus.MustNewConstMetric(
+				prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, routerSpace,
+						"sync_status"),
+					"Router synchronization status.",
+					append(routerLabelNames(), "sync_status"), nil,
+				), prometheus.GaugeValue,
+				float64(routerStatus(routers.Data[i].SyncStatus)),
+				append(routerLabelValues(&routers.Data[i]), routers.Data[i].SyncStatus)...,
+			)
+		}
+
+		syncStates := routerSyncStates
+		if routers.Data[i].SyncStatus != "" && !slices.Contains(syncStates, routers.Data[i].SyncStatus) {
+			syncStates = append(slices.Clone(syncStates), routers.Data[i].SyncStatus)
+		}
+
+		for _, syncState := range syncStates {
+			ch <- prometheus.MustNewConstMetric(
+				prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, routerSpace,
+						"sync_state"),
+					"Router synchronization state, 1 for the current state.",
+					append(routerLabelNames(), "sync_state"), nil,
+				), prometheus.GaugeValue,
+				convertBool2Float(syncState == routers.Data[i].SyncStatus),
+				append(routerLabelValues(&routers.Data[i]), syncState)...,
+			)
+		}
+
+		ch <- prometheus.MustNewConstMetric(
+			prometheus.NewDesc(
+				prometheus.BuildFQName(namespace, routerSpace,
+					"sync_status_changes_total"),
+				"Number of router synchronization status changes observed since the exporter start.",
+				routerLabelNames(), nil,
+			), prometheus.CounterValue,
+			c.syncStatusChanges(&routers.Data[i], seen),
+			routerLabelValues(&routers.Data[i])...,
 		)
 		ch <- prometheus.MustNewConstMetric(
 			prometheus.NewDesc(
 				prometheus.BuildFQName(namespace, routerSpace,
-					"sync_status"),
-				"Router synchronization status.",
-				[]string{"hostname", "role_attributes", "version", "sync_status"}, nil,
+					"verified"),
+				"Router enrollment is completed and verified.",
+				routerLabelNames(), nil,
 			), prometheus.GaugeValue,
-			float64(routerStatus(routers.Data[i].SyncStatus)),
-			routers.Data[i].Hostname,
-			strings.Join(routers.Data[i].RoleAttributes, " "),
+			convertBool2Float(routers.Data[i].IsVerified),
+			routerLabelValues(&routers.Data[i])...,
+		)
+
+		ch <- prometheus.MustNewConstMetric(
+			prometheus.NewDesc(
+				prometheus.BuildFQName(namespace, routerSpace,
+					"build_info"),
+				"Router version and build information.",
+				[]string{"id", "name", "version", "os", "arch", "revision", "build_date"}, nil,
+			), prometheus.GaugeValue,
+			1,
+			routers.Data[i].ID,
+			routers.Data[i].Name,
 			routers.Data[i].VersionInfo.Version,
-			routers.Data[i].SyncStatus,
+			routers.Data[i].VersionInfo.OS,
+			routers.Data[i].VersionInfo.Arch,
+			routers.Data[i].VersionInfo.Revision,
+			routers.Data[i].VersionInfo.BuildDate,
 		)
+
+		if routerRelease, err := parseVersion(routers.Data[i].VersionInfo.Version); controllerErr == nil && err == nil {
+			component, skew := versionSkew(controllerRelease, routerRelease)
+
+			ch <- prometheus.MustNewConstMetric(
+				prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, routerSpace,
+						"version_skew"),
+					"Router version difference with the controller version on the first differing component, positive when the router is behind.",
+					append(routerLabelNames(), "component"), nil,
+				), prometheus.GaugeValue,
+				float64(skew),
+				append(routerLabelValues(&routers.Data[i]), component)...,
+			)
+		}
+
+		if versionPolicy {
+			compliant := versionCompliant(routers.Data[i].VersionInfo.Version, minVersion)
+			if !compliant {
+				nonCompliant++
+			}
+
+			ch <- prometheus.MustNewConstMetric(
+				prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, "",
+						"version_compliant"),
+					"Router or identity SDK version is greater or equal to the configured minimum version.",
+					[]string{"kind", "name"}, nil,
+				), prometheus.GaugeValue,
+				convertBool2Float(compliant),
+				"router",
+				routers.Data[i].Name,
+			)
+		}
+
+		if !routers.Data[i].IsVerified && routers.Data[i].EnrollmentExpiresAt != "" {
+			ch <- prometheus.MustNewConstMetric(
+				prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, routerSpace,
+						"enrollment_expiry_timestamp_seconds"),
+					"Router enrollment token expiry timestamp.",
+					routerLabelNames(), nil,
+				), prometheus.GaugeValue,
+				convertRFC33339toUnix(routers.Data[i].EnrollmentExpiresAt),
+				routerLabelValues(&routers.Data[i])...,
+			)
+		}
 	}
 
+	c.pruneSyncStatuses(seen)
+
+	if versionPolicy {
+		ch <- prometheus.MustNewConstMetric(
+			prometheus.NewDesc(
+				prometheus.BuildFQName(namespace, "routers",
+					"version_non_compliant"),
+				"Number of routers running a version lower than the configured minimum version.",
+				nil, nil,
+			), prometheus.GaugeValue,
+			nonCompliant,
+		)
+	}
+
+	ch <- prometheus.MustNewConstMetric(
+		prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "routers",
+				"awaiting_enrollment"),
+			"Number of routers with a valid enrollment token not used yet.",
+			nil, nil,
+		), prometheus.GaugeValue,
+		enrollmentStates[routerEnrollmentAwaitingEnrollment],
+	)
+	ch <- prometheus.MustNewConstMetric(
+		prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "routers",
+				"enrollment_expired"),
+			"Number of routers never enrolled whose enrollment token expired.",
+			nil, nil,
+		), prometheus.GaugeValue,
+		enrollmentStates[routerEnrollmentExpired],
+	)
+	ch <- prometheus.MustNewConstMetric(
+		prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "routers",
+				"awaiting_verification"),
+			"Number of routers enrolled with an unverified fingerprint.",
+			nil, nil,
+		), prometheus.GaugeValue,
+		enrollmentStates[routerEnrollmentAwaitingVerification],
+	)
+
 	return nil
 }
 
 // RunRouters implements this command
-func (o *LoginOptions) RunRouters() (Routers, error) {
+func (o *LoginOptions) RunRouters(filter string) (Routers, error) {
 	var (
 		limit                           = 20
 		offset                          = 0
@@ -152,7 +385,7 @@ func (o *LoginOptions) RunRouters() (Routers, error) {
 		json                            = jsoniter.ConfigCompatibleWithStandardLibrary
 	)
 
-	jsonBytes, err := controllerAPICall(o, "edge_management", "/edge-routers", limit, offset)
+	jsonBytes, err := controllerAPICall(o, "edge_management", "/edge-routers", filter, limit, offset)
 	if err != nil {
 		return routerStructTotal, err
 	}
@@ -170,7 +403,7 @@ func (o *LoginOptions) RunRouters() (Routers, error) {
 	for offset+limit < totalRouterCount {
 		offset += limit
 
-		jsonBytes, err := controllerAPICall(o, "edge_management", "/edge-routers", limit, offset)
+		jsonBytes, err := controllerAPICall(o, "edge_management", "/edge-routers", filter, limit, offset)
 		if err != nil {
 			return routerStructTotal, err
 		}
@@ -186,6 +419,35 @@ func (o *LoginOptions) RunRouters() (Routers, error) {
 	return routerStructTotal, err
 }
 
+// syncStatusChanges records the current synchronization status of a router and returns its number of changes
+func (c *routersCollector) syncStatusChanges(router *Router, seen map[string]bool) float64 {
+	c.syncMtx.Lock()
+	defer c.syncMtx.Unlock()
+
+	seen[router.ID] = true
+
+	if previous, ok := c.syncStatuses[router.ID]; ok && previous != router.SyncStatus {
+		c.syncStatusMoves[router.ID]++
+	}
+
+	c.syncStatuses[router.ID] = router.SyncStatus
+
+	return c.syncStatusMoves[router.ID]
+}
+
+// pruneSyncStatuses forgets the routers not returned by the controller anymore
+func (c *routersCollector) pruneSyncStatuses(seen map[string]bool) {
+	c.syncMtx.Lock()
+	defer c.syncMtx.Unlock()
+
+	for id := range c.syncStatuses {
+		if !seen[id] {
+			delete(c.syncStatuses, id)
+			delete(c.syncStatusMoves, id)
+		}
+	}
+}
+
 // routerStatus maps the router status with an integer value
 func routerStatus(status string) int64 {
 	switch status {
@@ -0,0 +1,52 @@
+// Copyright 2023 enthus GmbH
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"strings"
+	"time"
+)
+
+// routerLabelNames returns the label names of the Router value metrics
+func routerLabelNames() []string {
+	if *legacyLabels {
+		return []string{"id", "name", "hostname", "role_attributes", "version"}
+	}
+
+	return []string{"id"}
+}
+
+// routerLabelValues returns the label values of the Router value metrics
+func routerLabelValues(router *Router) []string {
+	if *legacyLabels {
+		return []string{router.ID, router.Name, router.Hostname, strings.Join(router.RoleAttributes, " "), router.VersionInfo.Version}
+	}
+
+	return []string{router.ID}
+}
+
+// routerEnrollmentState returns the enrollment state of a Router: verified, waiting for its enrollment
+// (or with an expired enrollment token) or enrolled with a fingerprint still to be verified
+func routerEnrollmentState(router *Router, now time.Time) string {
+	switch {
+	case router.IsVerified:
+		return routerEnrollmentVerified
+	case router.UnverifiedFingerprint != "":
+		return routerEnrollmentAwaitingVerification
+	case router.EnrollmentExpiresAt != "" && convertRFC33339toUnix(router.EnrollmentExpiresAt) < float64(now.Unix()):
+		return routerEnrollmentExpired
+	default:
+		return routerEnrollmentAwaitingEnrollment
+	}
+}
@@ -32,19 +32,49 @@ const (
 	syncError               // sync failed due to an unexpected error
 )
 
+// routerSyncStates lists the known router synchronization states
+var routerSyncStates = []string{
+	"SYNC_NEW",
+	"SYNC_QUEUED",
+	"SYNC_HELLO_TIMEOUT",
+	"SYNC_HELLO",
+	"SYNC_HELLO_WAIT",
+	"SYNC_RESYNC_WAIT",
+	"SYNC_IN_PROGRESS",
+	"SYNC_DONE",
+	"SYNC_UNKNOWN",
+	"SYNC_DISCONNECTED",
+	"SYNC_ERROR",
+}
+
+const (
+	routerEnrollmentVerified             = "verified"
+	routerEnrollmentAwaitingEnrollment   = "awaiting_enrollment"
+	routerEnrollmentExpired              = "enrollment_expired"
+	routerEnrollmentAwaitingVerification = "awaiting_verification"
+)
+
 // Router represent the meaningful chracteristics of a Ziti Router
 // for this exporter
 type Router struct {
-	Disabled          bool     `json:"disabled"`
-	Hostname          string   `json:"hostname"`
-	IsOnline          bool     `json:"isOnline"`
-	Name              string   `json:"name"`
-	NoTraversal       bool     `json:"noTraversal"`
-	SyncStatus        string   `json:"syncStatus"`
-	IsTunnelerEnabled bool     `json:"isTunnelerEnabled"`
-	IsVerified        bool     `json:"isVerified"`
-	RoleAttributes    []string `json:"roleAttributes"`
-	VersionInfo       struct {
-		Version string `json:"version"`
+	Disabled              bool                   `json:"disabled"`
+	EnrollmentExpiresAt   string                 `json:"enrollmentExpiresAt"`
+	Hostname              string                 `json:"hostname"`
+	ID                    string                 `json:"id"`
+	IsOnline              bool                   `json:"isOnline"`
+	Name                  string                 `json:"name"`
+	NoTraversal           bool                   `json:"noTraversal"`
+	SyncStatus            string                 `json:"syncStatus"`
+	Tags                  map[string]interface{} `json:"tags"`
+	IsTunnelerEnabled     bool                   `json:"isTunnelerEnabled"`
+	IsVerified            bool                   `json:"isVerified"`
+	RoleAttributes        []string               `json:"roleAttributes"`
+	UnverifiedFingerprint string                 `json:"unverifiedFingerprint"`
+	VersionInfo           struct {
+		Arch      string `json:"arch"`
+		BuildDate string `json:"buildDate"`
+		OS        string `json:"os"`
+		Revision  string `json:"revision"`
+		Version   string `json:"version"`
 	} `json:"versionInfo"`
 }
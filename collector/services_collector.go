@@ -0,0 +1,128 @@
+// Copyright 2023 enthus GmbH
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/go-kit/log"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type servicesCollector struct {
+	logger  log.Logger
+	options *LoginOptions
+	filter  *entityFilter
+}
+
+const (
+	serviceSpace = "service"
+)
+
+func init() {
+	registerCollector("services", defaultDisabled, newServicesCollector)
+}
+
+// newServicesCollector returns a new Collector exposing OpenZiti Services metrics.
+func newServicesCollector(logger log.Logger, options *LoginOptions) (Collector, error) {
+	filter, err := zitiServiceEntityFilter.entityFilter()
+	if err != nil {
+		return nil, err
+	}
+
+	return &servicesCollector{
+		logger:  logger,
+		options: options,
+		filter:  filter,
+	}, nil
+}
+
+// Update pushes services metrics onto ch
+func (c *servicesCollector) Update(ch chan<- prometheus.Metric) (err error) {
+	// if not already logged, do the login.
+	if c.options == nil {
+		c.options, err = edgeAPILogin(c.logger)
+		if err != nil {
+			errString := fmt.Sprintf("%s", errors.Unwrap(err))
+			zitiLoginErrors[errString]++
+
+			return err
+		}
+
+		zitiLoginSuccess++
+	} else if c.options.Token == "" {
+		c.options, err = edgeAPILogin(c.logger)
+		if err != nil {
+			errString := fmt.Sprintf("%s", errors.Unwrap(err))
+			zitiLoginErrors[errString]++
+
+			return err
+		}
+
+		zitiLoginSuccess++
+	}
+
+	services, err := c.options.RunServices(*zitiServiceFilter)
+	if err != nil {
+		return err
+	}
+
+	for i := range services.Data {
+		if !c.filter.matches([]string{services.Data[i].Name}, services.Data[i].RoleAttributes, services.Data[i].Tags) {
+			continue
+		}
+
+		ch <- prometheus.MustNewConstMetric(
+			prometheus.NewDesc(
+				prometheus.BuildFQName(namespace, serviceSpace,
+					"info"),
+				"Service descriptive information.",
+				append([]string{"id", "name"}, tagLabelNames()...), nil,
+			), prometheus.GaugeValue,
+			1,
+			append([]string{
+				services.Data[i].ID,
+				services.Data[i].Name,
+			}, tagLabelValues(services.Data[i].Tags)...)...,
+		)
+		for _, roleAttr := range services.Data[i].RoleAttributes {
+			ch <- prometheus.MustNewConstMetric(
+				prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, serviceSpace,
+						"role_attribute"),
+					"Service role attribute, one series per attribute.",
+					[]string{"id", "service", "attribute"}, nil,
+				), prometheus.GaugeValue,
+				1,
+				services.Data[i].ID,
+				services.Data[i].Name,
+				roleAttr,
+			)
+		}
+
+		ch <- prometheus.MustNewConstMetric(
+			prometheus.NewDesc(
+				prometheus.BuildFQName(namespace, serviceSpace,
+					"encryption_required"),
+				"Service requires end-to-end encryption.",
+				[]string{"id"}, nil,
+			), prometheus.GaugeValue,
+			convertBool2Float(services.Data[i].EncryptionRequired),
+			services.Data[i].ID,
+		)
+	}
+
+	return nil
+}
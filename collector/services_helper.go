@@ -0,0 +1,62 @@
+// Copyright 2023 enthus GmbH
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"github.com/go-kit/log/level"
+	jsoniter "github.com/json-iterator/go"
+)
+
+// RunServices implements this command
+func (o *LoginOptions) RunServices(filter string) (Services, error) {
+	var (
+		limit                             = 50
+		offset                            = 0
+		serviceStructTotal, serviceStruct Services
+		json                              = jsoniter.ConfigCompatibleWithStandardLibrary
+	)
+
+	jsonBytes, err := controllerAPICall(o, "edge_management", "/services", filter, limit, offset)
+	if err != nil {
+		return serviceStructTotal, err
+	}
+
+	err = json.Unmarshal(jsonBytes, &serviceStruct)
+	if err != nil {
+		return serviceStructTotal, err
+	}
+
+	serviceStructTotal.Data = append(serviceStructTotal.Data, serviceStruct.Data...)
+
+	totalServiceCount := serviceStruct.Meta.Pagination.TotalCount
+	level.Debug(o.Logger).Log("msg", "Total Ziti Services found", "count", totalServiceCount)
+
+	for offset+limit < totalServiceCount {
+		offset += limit
+
+		jsonBytes, err := controllerAPICall(o, "edge_management", "/services", filter, limit, offset)
+		if err != nil {
+			return serviceStructTotal, err
+		}
+
+		err = json.Unmarshal(jsonBytes, &serviceStruct)
+		if err != nil {
+			return serviceStructTotal, err
+		}
+
+		serviceStructTotal.Data = append(serviceStructTotal.Data, serviceStruct.Data...)
+	}
+
+	return serviceStructTotal, err
+}
@@ -0,0 +1,30 @@
+// Copyright 2023 enthus GmbH
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+type Services struct {
+	Data []Service `json:"data"`
+	Meta MetaData  `json:"meta"`
+}
+
+// Service represent the meaningful chracteristics of a Ziti Service
+// for this exporter
+type Service struct {
+	Configs            []string               `json:"configs"`
+	EncryptionRequired bool                   `json:"encryptionRequired"`
+	ID                 string                 `json:"id"`
+	Name               string                 `json:"name"`
+	RoleAttributes     []string               `json:"roleAttributes"`
+	Tags               map[string]interface{} `json:"tags"`
+}
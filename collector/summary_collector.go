@@ -0,0 +1,116 @@
+// Copyright 2023 enthus GmbH
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type summaryCollector struct {
+	logger  log.Logger
+	options *LoginOptions
+}
+
+const (
+	summarySpace = "summary"
+)
+
+func init() {
+	registerCollector("summary", defaultDisabled, newSummaryCollector)
+}
+
+// newSummaryCollector returns a new Collector exposing OpenZiti entity counts metrics.
+func newSummaryCollector(logger log.Logger, options *LoginOptions) (Collector, error) {
+	return &summaryCollector{
+		logger:  logger,
+		options: options,
+	}, nil
+}
+
+// Update pushes summary metrics onto ch
+func (c *summaryCollector) Update(ch chan<- prometheus.Metric) (err error) {
+	// if not already logged, do the login.
+	if c.options == nil {
+		c.options, err = edgeAPILogin(c.logger)
+		if err != nil {
+			errString := fmt.Sprintf("%s", errors.Unwrap(err))
+			zitiLoginErrors[errString]++
+
+			return err
+		}
+
+		zitiLoginSuccess++
+	} else if c.options.Token == "" {
+		c.options, err = edgeAPILogin(c.logger)
+		if err != nil {
+			errString := fmt.Sprintf("%s", errors.Unwrap(err))
+			zitiLoginErrors[errString]++
+
+			return err
+		}
+
+		zitiLoginSuccess++
+	}
+
+	for _, api := range []string{"edge_management", "fabric"} {
+		summary, err := c.options.RunSummary(api)
+		if err != nil {
+			return err
+		}
+
+		for entityType, count := range summary.Data {
+			ch <- prometheus.MustNewConstMetric(
+				prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, summarySpace,
+						"entities"),
+					"Number of entities by type reported by the controller.",
+					[]string{"api", "type"}, nil,
+				), prometheus.GaugeValue,
+				count,
+				api,
+				entityType,
+			)
+		}
+	}
+
+	return nil
+}
+
+// RunSummary implements this command
+func (o *LoginOptions) RunSummary(api string) (Summary, error) {
+	var (
+		summaryStruct Summary
+		json          = jsoniter.ConfigCompatibleWithStandardLibrary
+	)
+
+	jsonBytes, err := controllerAPIGet(o, api, "/summary", nil)
+	if err != nil {
+		return summaryStruct, err
+	}
+
+	err = json.Unmarshal(jsonBytes, &summaryStruct)
+	if err != nil {
+		return summaryStruct, err
+	}
+
+	level.Debug(o.Logger).Log("msg", "Ziti entity types found", "api", api, "count", len(summaryStruct.Data))
+
+	return summaryStruct, err
+}
@@ -0,0 +1,225 @@
+// Copyright 2023 enthus GmbH
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"cmp"
+	"fmt"
+	"strconv"
+	"strings"
+
+	jsoniter "github.com/json-iterator/go"
+)
+
+// RunControllerVersion implements this command
+func (o *LoginOptions) RunControllerVersion() (ControllerVersion, error) {
+	var (
+		versionStruct ControllerVersion
+		json          = jsoniter.ConfigCompatibleWithStandardLibrary
+	)
+
+	jsonBytes, err := controllerAPIGet(o, "edge_management", "/version", nil)
+	if err != nil {
+		return versionStruct, err
+	}
+
+	err = json.Unmarshal(jsonBytes, &versionStruct)
+
+	return versionStruct, err
+}
+
+// parseVersion returns the major, minor and patch numbers and the prerelease of a version such as v1.1.9
+// or 1.2.0-rc1, the build metadata is ignored
+func parseVersion(version string) (semVersion, error) {
+	var parsed semVersion
+
+	trimmed := strings.TrimPrefix(strings.TrimSpace(version), "v")
+	if i := strings.IndexAny(trimmed, "+ "); i >= 0 {
+		trimmed = trimmed[:i]
+	}
+
+	trimmed, prerelease, _ := strings.Cut(trimmed, "-")
+
+	parts := strings.Split(trimmed, ".")
+	if trimmed == "" || len(parts) > len(parsed.release) {
+		return semVersion{}, fmt.Errorf("invalid version %q", version)
+	}
+
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return semVersion{}, fmt.Errorf("invalid version %q", version)
+		}
+
+		parsed.release[i] = n
+	}
+
+	parsed.prerelease = prerelease
+
+	return parsed, nil
+}
+
+// versionSkew returns the first version component differing between the controller and router versions
+// and their difference, positive when the router is behind, or "none" and 0 for the same versions
+func versionSkew(controller, router semVersion) (string, int) {
+	for i, component := range []string{"major", "minor", "patch"} {
+		if skew := controller.release[i] - router.release[i]; skew != 0 {
+			return component, skew
+		}
+	}
+
+	return "none", 0
+}
+
+// compareVersions returns -1, 0 or 1 when the version a is lower, equal or greater than the version b,
+// a prerelease being lower than its release
+func compareVersions(a, b semVersion) int {
+	for i := range a.release {
+		switch {
+		case a.release[i] < b.release[i]:
+			return -1
+		case a.release[i] > b.release[i]:
+			return 1
+		}
+	}
+
+	switch {
+	case a.prerelease == b.prerelease:
+		return 0
+	case a.prerelease == "":
+		return 1
+	case b.prerelease == "":
+		return -1
+	default:
+		return comparePrereleases(a.prerelease, b.prerelease)
+	}
+}
+
+// comparePrereleases compares the dot-separated identifiers of two prereleases, a prerelease with fewer
+// identifiers being lower when they are otherwise equal
+func comparePrereleases(a, b string) int {
+	aIdentifiers, bIdentifiers := strings.Split(a, "."), strings.Split(b, ".")
+
+	for i := 0; i < len(aIdentifiers) && i < len(bIdentifiers); i++ {
+		if c := comparePrereleaseIdentifiers(aIdentifiers[i], bIdentifiers[i]); c != 0 {
+			return c
+		}
+	}
+
+	return cmp.Compare(len(aIdentifiers), len(bIdentifiers))
+}
+
+// comparePrereleaseIdentifiers compares two prerelease identifiers run by run, the digit runs numerically,
+// so that rc2 is lower than rc10, and a digit run lower than a non-digit run
+func comparePrereleaseIdentifiers(a, b string) int {
+	for a != "" && b != "" {
+		aRun, bRun := prereleaseRun(a), prereleaseRun(b)
+		aDigits, bDigits := isDigit(aRun[0]), isDigit(bRun[0])
+
+		switch {
+		case aDigits && bDigits:
+			// the digit runs are compared without conversion, they can exceed an int
+			aNumber, bNumber := strings.TrimLeft(aRun, "0"), strings.TrimLeft(bRun, "0")
+			if c := cmp.Compare(len(aNumber), len(bNumber)); c != 0 {
+				return c
+			}
+
+			if c := strings.Compare(aNumber, bNumber); c != 0 {
+				return c
+			}
+		case aDigits:
+			return -1
+		case bDigits:
+			return 1
+		default:
+			if c := strings.Compare(aRun, bRun); c != 0 {
+				return c
+			}
+		}
+
+		a, b = a[len(aRun):], b[len(bRun):]
+	}
+
+	return cmp.Compare(len(a), len(b))
+}
+
+// prereleaseRun returns the leading run of digits or non-digits of a non-empty prerelease identifier
+func prereleaseRun(identifier string) string {
+	digits := isDigit(identifier[0])
+
+	i := 1
+	for i < len(identifier) && isDigit(identifier[i]) == digits {
+		i++
+	}
+
+	return identifier[:i]
+}
+
+// isDigit returns true if c is an ASCII digit
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+// versionCompliant returns true if version is greater or equal to minVersion, an invalid version is not compliant
+func versionCompliant(version string, minVersion semVersion) bool {
+	parsed, err := parseVersion(version)
+	if err != nil {
+		return false
+	}
+
+	return compareVersions(parsed, minVersion) >= 0
+}
+
+// routerMinVersion returns the minimum version required for routers, and false without router version policy
+func routerMinVersion() (semVersion, bool, error) {
+	if *zitiRouterMinVersion == "" {
+		return semVersion{}, false, nil
+	}
+
+	minVersion, err := parseVersion(*zitiRouterMinVersion)
+
+	return minVersion, err == nil, err
+}
+
+// sdkMinVersions returns the minimum version required per identity SDK type
+func sdkMinVersions() (map[string]semVersion, error) {
+	minVersions := make(map[string]semVersion)
+
+	for _, policy := range splitList(*zitiSdkMinVersions) {
+		sdkType, version, ok := strings.Cut(policy, "=")
+		if !ok || strings.TrimSpace(sdkType) == "" {
+			return nil, fmt.Errorf("invalid SDK version policy %q, expected <sdk type>=<version>", policy)
+		}
+
+		minVersion, err := parseVersion(version)
+		if err != nil {
+			return nil, fmt.Errorf("invalid SDK version policy %q: %w", policy, err)
+		}
+
+		minVersions[strings.TrimSpace(sdkType)] = minVersion
+	}
+
+	return minVersions, nil
+}
+
+// validateVersionPolicy checks the router and SDK minimum versions.
+func validateVersionPolicy() error {
+	if _, _, err := routerMinVersion(); err != nil {
+		return fmt.Errorf("invalid router version policy: %w", err)
+	}
+
+	_, err := sdkMinVersions()
+
+	return err
+}
@@ -0,0 +1,103 @@
+// Copyright 2023 enthus GmbH
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"testing"
+)
+
+func TestParseVersion(t *testing.T) {
+	for _, tc := range []struct {
+		version string
+		want    semVersion
+		wantErr bool
+	}{
+		{"v1.1.9", semVersion{release: [3]int{1, 1, 9}}, false},
+		{"1.2.0-rc1", semVersion{release: [3]int{1, 2, 0}, prerelease: "rc1"}, false},
+		{"v0.32", semVersion{release: [3]int{0, 32, 0}}, false},
+		{"v1.1.9+build", semVersion{release: [3]int{1, 1, 9}}, false},
+		{"", semVersion{}, true},
+		{"v1.x.0", semVersion{}, true},
+		{"1.2.3.4", semVersion{}, true},
+	} {
+		have, err := parseVersion(tc.version)
+		if (err != nil) != tc.wantErr {
+			t.Errorf("parseVersion(%q): want error %v, have %v", tc.version, tc.wantErr, err)
+			continue
+		}
+
+		if have != tc.want {
+			t.Errorf("parseVersion(%q): want %+v, have %+v", tc.version, tc.want, have)
+		}
+	}
+}
+
+func TestVersionCompliant(t *testing.T) {
+	for _, tc := range []struct {
+		version, minVersion string
+		want                bool
+	}{
+		{"v1.1.9", "v1.1.0", true},
+		{"v1.1.0", "v1.1.0", true},
+		{"v1.0.12", "v1.1.0", false},
+		{"v2.0.0", "v1.1.0", true},
+		{"1.2.0-rc1", "1.2.0", false},
+		{"1.2.0", "1.2.0-rc1", true},
+		{"1.2.0-rc2", "1.2.0-rc1", true},
+		{"1.2.0-rc10", "1.2.0-rc2", true},
+		{"1.2.0-rc2", "1.2.0-rc10", false},
+		{"1.2.0-rc.10", "1.2.0-rc.2", true},
+		{"1.2.0-rc.1", "1.2.0-rc", true},
+		{"1.2.0-1", "1.2.0-alpha", false},
+		{"", "v1.1.0", false},
+	} {
+		minVersion, err := parseVersion(tc.minVersion)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if have := versionCompliant(tc.version, minVersion); have != tc.want {
+			t.Errorf("versionCompliant(%q, %q): want %v, have %v", tc.version, tc.minVersion, tc.want, have)
+		}
+	}
+}
+
+func TestVersionSkew(t *testing.T) {
+	for _, tc := range []struct {
+		controller, router string
+		wantComponent      string
+		wantSkew           int
+	}{
+		{"1.2.0", "1.1.9", "minor", 1},
+		{"1.1.9", "1.2.0", "minor", -1},
+		{"2.0.0", "1.9.9", "major", 1},
+		{"1.1.9", "1.1.7", "patch", 2},
+		{"1.1.9", "1.1.9", "none", 0},
+	} {
+		controller, err := parseVersion(tc.controller)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		router, err := parseVersion(tc.router)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		component, skew := versionSkew(controller, router)
+		if component != tc.wantComponent || skew != tc.wantSkew {
+			t.Errorf("versionSkew(%q, %q): want %s %d, have %s %d", tc.controller, tc.router, tc.wantComponent, tc.wantSkew, component, skew)
+		}
+	}
+}
@@ -0,0 +1,30 @@
+// Copyright 2023 enthus GmbH
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+// ControllerVersion represent the version information returned by the Ziti controller
+type ControllerVersion struct {
+	Data struct {
+		BuildDate      string `json:"buildDate"`
+		Revision       string `json:"revision"`
+		RuntimeVersion string `json:"runtimeVersion"`
+		Version        string `json:"version"`
+	} `json:"data"`
+}
+
+// semVersion represent the major, minor and patch numbers and the prerelease of a parsed version
+type semVersion struct {
+	release    [3]int
+	prerelease string
+}
@@ -208,6 +208,13 @@ func main() {
 	runtime.GOMAXPROCS(*maxProcs)
 	level.Debug(logger).Log("msg", "Go MAXPROCS", "procs", runtime.GOMAXPROCS(0))
 
+	if collector.IdentityTypesFilterUsed() {
+		if err := collector.ValidateIdentityTypes(logger); err != nil {
+			level.Error(logger).Log("msg", "configuration error", "err", err)
+			os.Exit(1)
+		}
+	}
+
 	http.Handle(*metricsPath, newHandler(!*disableExporterMetrics, *maxRequests, logger))
 
 	if *metricsPath != "/" {